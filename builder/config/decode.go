@@ -0,0 +1,65 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"cuelang.org/go/cue/cuecontext"
+	"sigs.k8s.io/yaml"
+)
+
+// decode parses raw into a generic document tree (nested map[string]
+// interface{}, []interface{}, and JSON-compatible scalars) regardless of
+// its source format, so the rest of the loader can treat YAML, JSON, and
+// CUE sources uniformly.
+func decode(raw []byte, format Format) (interface{}, error) {
+	switch format {
+	case FormatJSON:
+		var doc interface{}
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return nil, fmt.Errorf("parsing json: %w", err)
+		}
+		return doc, nil
+	case FormatCUE:
+		ctx := cuecontext.New()
+		value := ctx.CompileBytes(raw)
+		if err := value.Err(); err != nil {
+			return nil, fmt.Errorf("parsing cue: %w", err)
+		}
+		jsonBytes, err := value.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("converting cue to json: %w", err)
+		}
+		var doc interface{}
+		if err := json.Unmarshal(jsonBytes, &doc); err != nil {
+			return nil, err
+		}
+		return doc, nil
+	default:
+		var doc interface{}
+		if err := yaml.Unmarshal(raw, &doc); err != nil {
+			return nil, fmt.Errorf("parsing yaml: %w", err)
+		}
+		return doc, nil
+	}
+}
+
+// toJSON re-marshals a decoded document tree to canonical JSON so it can be
+// fed to the JSON Schema validator and the strict decoder.
+func toJSON(doc interface{}) ([]byte, error) {
+	return json.Marshal(doc)
+}
+
+// unmarshalStrict decodes normalized into out, rejecting any field not
+// present on it. This runs in addition to the schema's own
+// additionalProperties:false so a drift between the schema and the Go type
+// fails loudly rather than silently dropping data.
+func unmarshalStrict(normalized []byte, out *Configuration) error {
+	dec := json.NewDecoder(bytes.NewReader(normalized))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return fmt.Errorf("decoding config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/uor-framework/client/registryclient/orasclient"
+)
+
+// resolveIncludes walks doc and replaces any map containing an "$include"
+// key with the parsed contents of the file it names (resolved relative to
+// baseDir), and any map containing a "$ref" key with the contents fetched
+// from the OCI config artifact it names. Both directives may nest, except
+// that an $include inside $ref-fetched content is rejected (see
+// resolveNode), and a config built this way can therefore be split across
+// local files and shared base configs published to a registry.
+func resolveIncludes(doc interface{}, baseDir string) (interface{}, error) {
+	return resolveNode(doc, baseDir, map[string]bool{}, false)
+}
+
+// resolveNode walks node looking for $include/$ref directives. remote marks
+// that node came from a $ref-fetched OCI config artifact rather than the
+// local filesystem: $include is rejected in that case, since there is no
+// local directory to resolve it against that the fetched content should be
+// trusted to name (it is reached over a trust boundary and has no
+// legitimate reason to read local files at all).
+func resolveNode(node interface{}, baseDir string, seen map[string]bool, remote bool) (interface{}, error) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if path, ok := v["$include"].(string); ok {
+			if remote {
+				return nil, fmt.Errorf("$include %q: not allowed inside a $ref-fetched config", path)
+			}
+			return resolveIncludeDirective(path, baseDir, seen)
+		}
+		if ref, ok := v["$ref"].(string); ok {
+			return resolveRefDirective(ref, seen)
+		}
+
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			resolved, err := resolveNode(val, baseDir, seen, remote)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = resolved
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			resolved, err := resolveNode(val, baseDir, seen, remote)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+func resolveIncludeDirective(relPath string, baseDir string, seen map[string]bool) (interface{}, error) {
+	path, err := orasclient.ContainedPath(baseDir, relPath)
+	if err != nil {
+		return nil, fmt.Errorf("$include %q: %w", relPath, err)
+	}
+	if seen[path] {
+		return nil, fmt.Errorf("$include cycle detected at %q", path)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading included config %q: %w", path, err)
+	}
+
+	doc, err := decode(raw, formatFromExt(path))
+	if err != nil {
+		return nil, fmt.Errorf("parsing included config %q: %w", path, err)
+	}
+
+	// seen is forked, not mutated in place, so two sibling fields that
+	// $include the same file (a shared-base-config diamond, not a cycle)
+	// don't see each other's entries; only an include chain that actually
+	// revisits path trips the check above.
+	return resolveNode(doc, filepath.Dir(path), withSeen(seen, path), false)
+}
+
+// resolveRefDirective fetches a dataset config fragment from an
+// OCI-referenced config artifact, e.g. "oci://ghcr.io/org/base-config:v1"
+// or, with a JSON pointer fragment, "oci://ghcr.io/org/base-config:v1#/collections/0".
+func resolveRefDirective(ref string, seen map[string]bool) (interface{}, error) {
+	if seen[ref] {
+		return nil, fmt.Errorf("$ref cycle detected at %q", ref)
+	}
+
+	reference, pointer, _ := strings.Cut(strings.TrimPrefix(ref, "oci://"), "#")
+
+	client, err := orasclient.NewClient(reference)
+	if err != nil {
+		return nil, fmt.Errorf("configuring client for %q: %w", reference, err)
+	}
+
+	data, err := client.FetchConfig(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("fetching config artifact %q: %w", reference, err)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing config artifact %q: %w", reference, err)
+	}
+
+	if pointer != "" {
+		doc, err = jsonPointer(doc, pointer)
+		if err != nil {
+			return nil, fmt.Errorf("resolving %q in %q: %w", pointer, reference, err)
+		}
+	}
+
+	// Resolved as remote: a nested $include inside a $ref-fetched fragment
+	// is rejected outright rather than resolved against the process's CWD,
+	// since a malicious or compromised $ref source could otherwise splice
+	// arbitrary local file contents (e.g. "../../../home/user/.ssh/id_rsa")
+	// into the resolved config.
+	return resolveNode(doc, "", withSeen(seen, ref), true)
+}
+
+// withSeen returns a copy of seen with key added, leaving seen itself
+// untouched so sibling branches of the document tree don't share mutations
+// made while resolving one another's $include/$ref chains.
+func withSeen(seen map[string]bool, key string) map[string]bool {
+	next := make(map[string]bool, len(seen)+1)
+	for k, v := range seen {
+		next[k] = v
+	}
+	next[key] = true
+	return next
+}
+
+// jsonPointer resolves an RFC 6901 JSON pointer (e.g. "/collections/0")
+// against doc.
+func jsonPointer(doc interface{}, pointer string) (interface{}, error) {
+	current := doc
+	for _, tokenRaw := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token := strings.NewReplacer("~1", "/", "~0", "~").Replace(tokenRaw)
+		switch v := current.(type) {
+		case map[string]interface{}:
+			next, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("no such field %q", token)
+			}
+			current = next
+		case []interface{}:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil, fmt.Errorf("invalid array index %q", token)
+			}
+			current = v[idx]
+		default:
+			return nil, fmt.Errorf("cannot index into scalar at %q", token)
+		}
+	}
+	return current, nil
+}
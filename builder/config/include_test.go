@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestResolveIncludes_Diamond ensures two sibling fields that $include the
+// same shared file are both resolved, rather than the second occurrence
+// being rejected as a cycle.
+func TestResolveIncludes_Diamond(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "shared.json"), []byte(`{"location": "shared-dataset"}`), 0644))
+
+	doc := map[string]interface{}{
+		"first":  map[string]interface{}{"$include": "shared.json"},
+		"second": map[string]interface{}{"$include": "shared.json"},
+	}
+
+	resolved, err := resolveIncludes(doc, dir)
+	require.NoError(t, err)
+
+	out, ok := resolved.(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, map[string]interface{}{"location": "shared-dataset"}, out["first"])
+	require.Equal(t, map[string]interface{}{"location": "shared-dataset"}, out["second"])
+}
+
+// TestResolveIncludes_Cycle ensures a file that (transitively) includes
+// itself is still rejected.
+func TestResolveIncludes_Cycle(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"$include": "b.json"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.json"), []byte(`{"$include": "a.json"}`), 0644))
+
+	_, err := resolveIncludeDirective("a.json", dir, map[string]bool{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cycle")
+}
+
+// TestResolveIncludeDirective_RejectsEscapes ensures a $include can't read
+// a file outside baseDir via an absolute path or a "../" traversal.
+func TestResolveIncludeDirective_RejectsEscapes(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := resolveIncludeDirective("../../etc/passwd", dir, map[string]bool{})
+	require.Error(t, err)
+
+	_, err = resolveIncludeDirective("/etc/passwd", dir, map[string]bool{})
+	require.Error(t, err)
+}
+
+// TestResolveNode_RejectsIncludeInsideRemoteFragment ensures a $include
+// found inside a document fetched via $ref (and therefore marked remote)
+// is rejected outright rather than resolved against the process's working
+// directory, closing off reading arbitrary local files through a
+// malicious or compromised $ref-published config.
+func TestResolveNode_RejectsIncludeInsideRemoteFragment(t *testing.T) {
+	doc := map[string]interface{}{
+		"nested": map[string]interface{}{"$include": "../../../etc/passwd"},
+	}
+
+	_, err := resolveNode(doc, "", map[string]bool{}, true)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not allowed inside a $ref-fetched config")
+}
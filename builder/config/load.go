@@ -1,27 +1,104 @@
 package config
 
 import (
-	"github.com/spf13/viper"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/uor-framework/uor-client-go/builder/api/v1alpha1"
+
+	"github.com/uor-framework/client/builder/parser"
 )
 
-func ReadConfig(configName string) (v1alpha1.DataSetConfiguration, error) {
-	var configuration v1alpha1.DataSetConfiguration
+// Configuration is a dataset config after loading: the upstream
+// v1alpha1.DataSetConfiguration plus this repo's `plugins` extension for
+// declaring parser plugins to fetch before a build runs, e.g.:
+//
+//	plugins:
+//	  - source: ghcr.io/org/yaml-parser:v1
+type Configuration struct {
+	v1alpha1.DataSetConfiguration
+	Plugins []parser.PluginSource `json:"plugins,omitempty"`
+}
+
+// Format identifies the serialization a dataset config is encoded in.
+type Format string
+
+const (
+	// FormatAuto detects the format from the source file extension, and
+	// falls back to YAML for an io.Reader with no associated path.
+	FormatAuto Format = ""
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+	FormatCUE  Format = "cue"
+)
+
+func formatFromExt(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".cue":
+		return FormatCUE
+	default:
+		return FormatYAML
+	}
+}
+
+// ReadConfig loads and validates the dataset config at path, detecting its
+// format (YAML, JSON, or CUE) from the file extension and resolving any
+// $include directives relative to path's directory.
+func ReadConfig(path string) (Configuration, error) {
+	var configuration Configuration
+
+	file, err := os.Open(path)
+	if err != nil {
+		return configuration, fmt.Errorf("opening config %q: %w", path, err)
+	}
+	defer file.Close()
+
+	return Load(file, formatFromExt(path), filepath.Dir(path))
+}
+
+// Load decodes a dataset config from r, resolves $include/$ref composition
+// relative to baseDir, and validates the result against the embedded
+// DataSetConfiguration schema, rejecting unknown fields with line-numbered
+// error messages.
+func Load(r io.Reader, format Format, baseDir string) (Configuration, error) {
+	var configuration Configuration
 
-	viper.SetConfigName(configName)
-	viper.AddConfigPath(".")
-	viper.SetConfigType("yaml")
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return configuration, fmt.Errorf("reading config: %w", err)
+	}
 
-	err := viper.ReadInConfig()
+	if format == FormatAuto {
+		format = FormatYAML
+	}
+
+	doc, err := decode(raw, format)
 	if err != nil {
 		return configuration, err
 	}
 
-	err = viper.Unmarshal(&configuration)
+	resolved, err := resolveIncludes(doc, baseDir)
+	if err != nil {
+		return configuration, fmt.Errorf("resolving $include: %w", err)
+	}
+
+	normalized, err := toJSON(resolved)
 	if err != nil {
 		return configuration, err
 	}
 
-	return configuration, err
+	if err := validate(normalized); err != nil {
+		return configuration, err
+	}
+
+	if err := unmarshalStrict(normalized, &configuration); err != nil {
+		return configuration, err
+	}
+
+	return configuration, nil
 }
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_YAML(t *testing.T) {
+	in := strings.NewReader(`
+apiVersion: uor.client/v1alpha1
+kind: DataSetConfiguration
+collections:
+  - location: my-dataset
+    files:
+      - file: manifest.yaml
+`)
+
+	cfg, err := Load(in, FormatYAML, "")
+	require.NoError(t, err)
+	require.Len(t, cfg.Collections, 1)
+	require.Equal(t, "my-dataset", cfg.Collections[0].Location)
+}
+
+func TestLoad_RejectsUnknownField(t *testing.T) {
+	in := strings.NewReader(`{
+  "apiVersion": "uor.client/v1alpha1",
+  "kind": "DataSetConfiguration",
+  "collections": [{"location": "my-dataset", "oops": true}]
+}`)
+
+	_, err := Load(in, FormatJSON, "")
+	require.Error(t, err)
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+}
+
+func TestLoad_RejectsBadAPIVersion(t *testing.T) {
+	in := strings.NewReader(`{"apiVersion": "v2", "kind": "DataSetConfiguration"}`)
+
+	_, err := Load(in, FormatJSON, "")
+	require.Error(t, err)
+}
+
+func TestLoad_Plugins(t *testing.T) {
+	in := strings.NewReader(`{
+  "apiVersion": "uor.client/v1alpha1",
+  "kind": "DataSetConfiguration",
+  "plugins": [{"source": "ghcr.io/org/yaml-parser:v1"}]
+}`)
+
+	cfg, err := Load(in, FormatJSON, "")
+	require.NoError(t, err)
+	require.Len(t, cfg.Plugins, 1)
+	require.Equal(t, "ghcr.io/org/yaml-parser:v1", cfg.Plugins[0].Source)
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+//go:embed schema.json
+var schemaDoc []byte
+
+// schema is compiled once at package init from the embedded
+// DataSetConfiguration schema. Keeping it in sync with the v1alpha1 types
+// is a manual step today; see schema.json's description.
+var schema = mustCompileSchema()
+
+func mustCompileSchema() *jsonschema.Schema {
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("dataset-configuration.json", bytes.NewReader(schemaDoc)); err != nil {
+		panic(fmt.Sprintf("loading embedded config schema: %v", err))
+	}
+	compiled, err := compiler.Compile("dataset-configuration.json")
+	if err != nil {
+		panic(fmt.Sprintf("compiling embedded config schema: %v", err))
+	}
+	return compiled
+}
+
+// ValidationError reports a single schema violation in a decoded dataset
+// config. Line is the 1-based line the violating field appears on in a
+// pretty-printed rendering of the resolved config, i.e. after $include and
+// $ref composition rather than in whichever source file defined the field.
+type ValidationError struct {
+	Line    int
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("line %d: %s: %s", e.Line, e.Path, e.Message)
+}
+
+// validate checks normalized (canonical JSON produced by toJSON) against the
+// embedded DataSetConfiguration schema, returning a *ValidationError for
+// every violation, joined with errors.Join.
+func validate(normalized []byte) error {
+	var doc interface{}
+	if err := json.Unmarshal(normalized, &doc); err != nil {
+		return fmt.Errorf("parsing config for validation: %w", err)
+	}
+
+	err := schema.Validate(doc)
+	if err == nil {
+		return nil
+	}
+
+	valErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return fmt.Errorf("validating config: %w", err)
+	}
+
+	pretty, mErr := json.MarshalIndent(doc, "", "  ")
+	if mErr != nil {
+		pretty = normalized
+	}
+	lines := pointerLines(pretty)
+
+	var errs []error
+	for _, cause := range leafCauses(valErr) {
+		path := cause.InstanceLocation
+		if path == "" {
+			path = "/"
+		}
+		errs = append(errs, &ValidationError{
+			Line:    lines[cause.InstanceLocation],
+			Path:    path,
+			Message: cause.Message,
+		})
+	}
+	return errors.Join(errs...)
+}
+
+// leafCauses flattens a *jsonschema.ValidationError tree down to its leaf
+// causes, which carry the most specific, and most actionable, message for
+// each violation.
+func leafCauses(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	var out []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		out = append(out, leafCauses(cause)...)
+	}
+	return out
+}
+
+// pointerLines walks pretty, a json.MarshalIndent-formatted document, and
+// returns the line each JSON Pointer location starts on, keyed the same way
+// jsonschema.ValidationError.InstanceLocation is (e.g. "/collections/0").
+func pointerLines(pretty []byte) map[string]int {
+	dec := json.NewDecoder(bytes.NewReader(pretty))
+	lines := map[string]int{"": 1}
+
+	lineAt := func(offset int64) int {
+		// Before an array element other than the first, the offset still
+		// sits on the ',' that ends the previous element; skip it and the
+		// indentation after it so the element's own line is counted.
+		for offset < int64(len(pretty)) && (pretty[offset] == ',' || pretty[offset] == ' ' || pretty[offset] == '\n') {
+			offset++
+		}
+		return 1 + bytes.Count(pretty[:offset], []byte("\n"))
+	}
+
+	var walk func(path string)
+	walk = func(path string) {
+		tok, err := dec.Token()
+		if err != nil {
+			return
+		}
+		delim, ok := tok.(json.Delim)
+		if !ok {
+			return
+		}
+		switch delim {
+		case '{':
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return
+				}
+				key, _ := keyTok.(string)
+				childPath := path + "/" + escapePointerToken(key)
+				lines[childPath] = lineAt(dec.InputOffset())
+				walk(childPath)
+			}
+			_, _ = dec.Token() // consume closing '}'
+		case '[':
+			for i := 0; dec.More(); i++ {
+				childPath := fmt.Sprintf("%s/%d", path, i)
+				lines[childPath] = lineAt(dec.InputOffset())
+				walk(childPath)
+			}
+			_, _ = dec.Token() // consume closing ']'
+		}
+	}
+	walk("")
+
+	return lines
+}
+
+func escapePointerToken(tok string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(tok)
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// baseParser implements the bookkeeping shared by the built-in
+// template-based parsers.
+type baseParser struct {
+	linkFuncs []func(interface{}) bool
+}
+
+func (p *baseParser) AddFuncs(fn func(interface{}) bool) {
+	p.linkFuncs = append(p.linkFuncs, fn)
+}
+
+func (p *baseParser) isLink(value interface{}) bool {
+	for _, fn := range p.linkFuncs {
+		if fn(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectLinks walks a decoded document and records every leaf value that
+// at least one registered link function recognizes as a reference to
+// another workspace file, keyed by its dotted field path.
+func collectLinks(prefix string, value interface{}, isLink func(interface{}) bool, links map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			collectLinks(joinPath(prefix, key), child, isLink, links)
+		}
+	case []interface{}:
+		for i, child := range v {
+			collectLinks(fmt.Sprintf("%s[%d]", prefix, i), child, isLink, links)
+		}
+	default:
+		if isLink(value) {
+			links[prefix] = value
+		}
+	}
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return strings.Join([]string{prefix, key}, ".")
+}
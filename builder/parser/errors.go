@@ -0,0 +1,13 @@
+package parser
+
+import "fmt"
+
+// ErrInvalidFormat is returned by ByContentType when no parser, built-in or
+// plugin-provided, is registered for a file's content type.
+type ErrInvalidFormat struct {
+	Path string
+}
+
+func (e *ErrInvalidFormat) Error() string {
+	return fmt.Sprintf("%s: no parser registered for this content type", e.Path)
+}
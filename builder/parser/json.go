@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+)
+
+// MediaTypeJSON is the media type registered for JSON workspace files.
+const MediaTypeJSON = "application/vnd.emporous.json"
+
+func init() {
+	Register(MediaTypeJSON, func() Parser { return &jsonParser{} }, ".json")
+}
+
+type jsonParser struct {
+	baseParser
+}
+
+func (p *jsonParser) GetLinkableData(data []byte) (interface{}, map[string]interface{}, error) {
+	tmpl, err := template.New("json").Parse(string(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing json template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return nil, nil, fmt.Errorf("executing json template: %w", err)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		return nil, nil, fmt.Errorf("unmarshalling json: %w", err)
+	}
+
+	links := map[string]interface{}{}
+	collectLinks("", doc, p.isLink, links)
+	return doc, links, nil
+}
@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// Parser templates file content and extracts any links to other files in
+// the workspace.
+type Parser interface {
+	// AddFuncs registers a function used to determine whether a templated
+	// value refers to another file in the workspace.
+	AddFuncs(fn func(interface{}) bool)
+	// GetLinkableData renders the template in data and returns the
+	// resulting template value along with any links discovered while
+	// rendering it, keyed by the field path the link was found at.
+	GetLinkableData(data []byte) (template interface{}, links map[string]interface{}, err error)
+}
+
+// ParserFactory returns a new Parser instance. A factory is called once per
+// file so parsers do not need to be safe for concurrent reuse.
+type ParserFactory func() Parser
+
+var (
+	mu             sync.RWMutex
+	registry       = map[string]ParserFactory{}
+	mediaTypeByExt = map[string]string{}
+)
+
+// Register associates a parser factory with a media type, and the file
+// extensions that should resolve to it. Register is typically called from
+// an init function of a built-in parser or by LoadPlugins on behalf of an
+// external one. A later Register call for the same media type replaces the
+// earlier factory.
+func Register(mediaType string, factory ParserFactory, extensions ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[mediaType] = factory
+	for _, ext := range extensions {
+		mediaTypeByExt[ext] = mediaType
+	}
+}
+
+// ByContentType returns a Parser capable of handling the file at path based
+// on its extension. It returns an *ErrInvalidFormat if no parser, built-in
+// or plugin-provided, has been registered for that extension.
+func ByContentType(path string, _ []byte) (Parser, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	mediaType, ok := mediaTypeByExt[filepath.Ext(path)]
+	if !ok {
+		return nil, &ErrInvalidFormat{Path: path}
+	}
+
+	factory, ok := registry[mediaType]
+	if !ok {
+		return nil, &ErrInvalidFormat{Path: path}
+	}
+
+	return factory(), nil
+}
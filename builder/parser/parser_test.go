@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stubParser struct{ baseParser }
+
+func (stubParser) GetLinkableData(data []byte) (interface{}, map[string]interface{}, error) {
+	return string(data), nil, nil
+}
+
+func TestRegister_ByContentType(t *testing.T) {
+	Register("application/vnd.test.stub", func() Parser { return &stubParser{} }, ".stub")
+
+	p, err := ByContentType("config.stub", nil)
+	require.NoError(t, err)
+	require.IsType(t, &stubParser{}, p)
+}
+
+func TestByContentType_UnregisteredExtension(t *testing.T) {
+	_, err := ByContentType("config.unknown-ext", nil)
+	require.Error(t, err)
+
+	var invalid *ErrInvalidFormat
+	require.ErrorAs(t, err, &invalid)
+}
+
+func TestRegister_LaterCallReplacesFactory(t *testing.T) {
+	const mediaType = "application/vnd.test.replaceable"
+	Register(mediaType, func() Parser { return &jsonParser{} }, ".replaceable")
+	Register(mediaType, func() Parser { return &stubParser{} }, ".replaceable")
+
+	p, err := ByContentType("config.replaceable", nil)
+	require.NoError(t, err)
+	require.IsType(t, &stubParser{}, p)
+}
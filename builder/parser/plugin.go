@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"plugin"
+	"strings"
+
+	"github.com/uor-framework/client/registryclient/orasclient"
+)
+
+// PluginSource describes where to fetch a parser plugin from, as declared
+// in a dataset config's `plugins` list. Digest pins the expected sha256
+// digest of the fetched artifact's .so/.wasm file; it is required for a
+// native Go plugin, since plugin.Open runs arbitrary code in the host
+// process with no sandboxing, and is ignored for WASM.
+type PluginSource struct {
+	Source string `json:"source"`
+	Digest string `json:"digest,omitempty"`
+}
+
+// LoadPlugins fetches each plugin in sources from its OCI source, caching
+// the artifact under cacheDir, and registers the media-type handlers it
+// declares. WASM modules are registered through a sandboxed adapter, so an
+// untrusted WASM parser cannot affect the host process. Go plugins (.so)
+// are loaded with plugin.Open and run unsandboxed and fully trusted inside
+// the host process, so they are only loaded when src.Digest pins the exact
+// artifact content the caller intends to run.
+func LoadPlugins(ctx context.Context, sources []PluginSource, cacheDir string) error {
+	for _, src := range sources {
+		path, err := fetchPlugin(ctx, src, cacheDir)
+		if err != nil {
+			return fmt.Errorf("fetching plugin %q: %w", src.Source, err)
+		}
+
+		switch filepath.Ext(path) {
+		case ".so":
+			if err := verifyPluginDigest(src, path); err != nil {
+				return fmt.Errorf("loading plugin %q: %w", src.Source, err)
+			}
+			if err := loadGoPlugin(path); err != nil {
+				return fmt.Errorf("loading plugin %q: %w", src.Source, err)
+			}
+		case ".wasm":
+			if err := loadWASMPlugin(ctx, path); err != nil {
+				return fmt.Errorf("loading plugin %q: %w", src.Source, err)
+			}
+		default:
+			return fmt.Errorf("plugin %q: unrecognized artifact extension %q", src.Source, filepath.Ext(path))
+		}
+	}
+	return nil
+}
+
+// fetchPlugin pulls the plugin OCI artifact referenced by src into a
+// directory under cacheDir, reusing a previously cached copy when present,
+// and returns the path to the single .so or .wasm file the artifact
+// contained. The artifact's OCI reference (e.g. "ghcr.io/org/yaml-parser:v1")
+// is only used to name the cache directory: it almost never ends in .so or
+// .wasm itself, so the plugin kind must come from the file the client
+// actually wrote, which retains its real name from the artifact's layer
+// annotations.
+func fetchPlugin(ctx context.Context, src PluginSource, cacheDir string) (string, error) {
+	pluginsDir := filepath.Join(cacheDir, "plugins")
+	destDir, err := orasclient.ContainedPath(pluginsDir, filepath.FromSlash(src.Source))
+	if err != nil {
+		return "", fmt.Errorf("plugin %q: %w", src.Source, err)
+	}
+
+	if path, err := findPluginFile(destDir); err == nil {
+		return path, nil
+	}
+
+	if err := os.MkdirAll(destDir, 0750); err != nil {
+		return "", err
+	}
+
+	client, err := orasclient.NewClient(src.Source)
+	if err != nil {
+		return "", err
+	}
+	if err := client.Pull(ctx, destDir); err != nil {
+		return "", err
+	}
+
+	return findPluginFile(destDir)
+}
+
+// findPluginFile returns the path of the single .so or .wasm file directly
+// under dir, the layout a plugin artifact is expected to pull down as.
+func findPluginFile(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch filepath.Ext(entry.Name()) {
+		case ".so", ".wasm":
+			return filepath.Join(dir, entry.Name()), nil
+		}
+	}
+	return "", fmt.Errorf("no .so or .wasm file found in %q", dir)
+}
+
+// verifyPluginDigest requires src.Digest to be set and to match the sha256
+// digest of the file at path, so a Go plugin is only ever loaded when the
+// caller explicitly pinned the exact bytes they intend to run in-process.
+func verifyPluginDigest(src PluginSource, path string) error {
+	if src.Digest == "" {
+		return errors.New(`a pinned sha256 digest is required to load a native Go plugin, which runs unsandboxed in the host process; set "digest" on the plugin source`)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+
+	want := strings.TrimPrefix(src.Digest, "sha256:")
+	if got != want {
+		return fmt.Errorf("digest mismatch: fetched artifact is %q, expected %q", got, src.Digest)
+	}
+	return nil
+}
+
+// loadGoPlugin loads a compiled Go plugin and invokes its exported Register
+// function, passing this package's Register so the plugin can add its
+// media-type handlers to the shared registry.
+func loadGoPlugin(path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return err
+	}
+
+	sym, err := p.Lookup("Register")
+	if err != nil {
+		return err
+	}
+
+	register, ok := sym.(func(func(string, ParserFactory, ...string)))
+	if !ok {
+		return fmt.Errorf("plugin does not export a compatible Register function")
+	}
+
+	register(Register)
+	return nil
+}
@@ -0,0 +1,6 @@
+package parser
+
+// Version identifies the built-in parsing behavior of this package. It is
+// mixed into content-addressed cache keys elsewhere in the builder so that
+// a change here invalidates previously cached parse results.
+const Version = "1"
@@ -0,0 +1,148 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+type mediaTypeDecl struct {
+	Name       string   `json:"name"`
+	Extensions []string `json:"extensions"`
+}
+
+type mediaTypeManifest struct {
+	MediaTypes []mediaTypeDecl `json:"mediaTypes"`
+}
+
+// loadWASMPlugin compiles the WASM module at path, instantiates it once to
+// read the media types it declares, and registers a factory that spins up
+// a fresh instance per file so parser state never leaks between files.
+func loadWASMPlugin(ctx context.Context, path string) error {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	runtime := wazero.NewRuntime(ctx)
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		_ = runtime.Close(ctx)
+		return fmt.Errorf("instantiating wasi: %w", err)
+	}
+
+	module, err := runtime.CompileModule(ctx, code)
+	if err != nil {
+		_ = runtime.Close(ctx)
+		return fmt.Errorf("compiling wasm module: %w", err)
+	}
+
+	manifest, err := declaredMediaTypes(ctx, runtime, module)
+	if err != nil {
+		_ = runtime.Close(ctx)
+		return err
+	}
+
+	for _, mt := range manifest.MediaTypes {
+		mt := mt
+		Register(mt.Name, func() Parser {
+			return &wasmParser{runtime: runtime, module: module}
+		}, mt.Extensions...)
+	}
+
+	return nil
+}
+
+// declaredMediaTypes instantiates the module briefly to call its exported
+// `media_types` function, which must return a (pointer<<32 | length) pair
+// pointing at a JSON-encoded mediaTypeManifest in the module's memory.
+func declaredMediaTypes(ctx context.Context, runtime wazero.Runtime, module wazero.CompiledModule) (mediaTypeManifest, error) {
+	var manifest mediaTypeManifest
+
+	instance, err := runtime.InstantiateModule(ctx, module, wazero.NewModuleConfig())
+	if err != nil {
+		return manifest, fmt.Errorf("instantiating wasm module: %w", err)
+	}
+	defer instance.Close(ctx)
+
+	fn := instance.ExportedFunction("media_types")
+	if fn == nil {
+		return manifest, fmt.Errorf("wasm module does not export media_types")
+	}
+
+	results, err := fn.Call(ctx)
+	if err != nil {
+		return manifest, fmt.Errorf("calling media_types: %w", err)
+	}
+
+	ptr, size := uint32(results[0]>>32), uint32(results[0])
+	data, ok := instance.Memory().Read(ptr, size)
+	if !ok {
+		return manifest, fmt.Errorf("reading media_types result from wasm memory")
+	}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("decoding media_types manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// wasmParser delegates templating and link extraction to an exported
+// `get_linkable_data` function in a sandboxed WASM module instance. Link
+// functions registered via AddFuncs run in the host and are not currently
+// visible to the module; the module is responsible for identifying its own
+// link fields and returning them in its result.
+type wasmParser struct {
+	baseParser
+	runtime wazero.Runtime
+	module  wazero.CompiledModule
+}
+
+func (p *wasmParser) GetLinkableData(data []byte) (interface{}, map[string]interface{}, error) {
+	ctx := context.Background()
+	instance, err := p.runtime.InstantiateModule(ctx, p.module, wazero.NewModuleConfig())
+	if err != nil {
+		return nil, nil, fmt.Errorf("instantiating wasm module: %w", err)
+	}
+	defer instance.Close(ctx)
+
+	malloc := instance.ExportedFunction("malloc")
+	fn := instance.ExportedFunction("get_linkable_data")
+	if malloc == nil || fn == nil {
+		return nil, nil, fmt.Errorf("wasm module does not export malloc/get_linkable_data")
+	}
+
+	allocated, err := malloc.Call(ctx, uint64(len(data)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("allocating wasm memory: %w", err)
+	}
+	ptr := uint32(allocated[0])
+	if !instance.Memory().Write(ptr, data) {
+		return nil, nil, fmt.Errorf("writing input to wasm memory")
+	}
+
+	out, err := fn.Call(ctx, uint64(ptr), uint64(len(data)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("calling get_linkable_data: %w", err)
+	}
+
+	outPtr, outSize := uint32(out[0]>>32), uint32(out[0])
+	raw, ok := instance.Memory().Read(outPtr, outSize)
+	if !ok {
+		return nil, nil, fmt.Errorf("reading get_linkable_data result from wasm memory")
+	}
+
+	var result struct {
+		Template interface{}            `json:"template"`
+		Links    map[string]interface{} `json:"links"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, nil, fmt.Errorf("decoding get_linkable_data result: %w", err)
+	}
+
+	return result.Template, result.Links, nil
+}
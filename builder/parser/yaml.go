@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+)
+
+// MediaTypeYAML is the media type registered for YAML workspace files.
+const MediaTypeYAML = "application/vnd.emporous.yaml"
+
+func init() {
+	Register(MediaTypeYAML, func() Parser { return &yamlParser{} }, ".yaml", ".yml")
+}
+
+type yamlParser struct {
+	baseParser
+}
+
+func (p *yamlParser) GetLinkableData(data []byte) (interface{}, map[string]interface{}, error) {
+	tmpl, err := template.New("yaml").Parse(string(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing yaml template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return nil, nil, fmt.Errorf("executing yaml template: %w", err)
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		return nil, nil, fmt.Errorf("unmarshalling yaml: %w", err)
+	}
+
+	links := map[string]interface{}{}
+	collectLinks("", doc, p.isLink, links)
+	return doc, links, nil
+}
@@ -0,0 +1,151 @@
+package pipeline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// cacheEntry is the persisted result of parsing and templating a single
+// workspace file, keyed by the content hash of its inputs. LinkTargets
+// records the workspace paths Links resolved against at write time, so a
+// stale hit (an entry whose link target no longer exists) can be detected
+// without folding the whole workspace's file list into the key itself.
+type cacheEntry struct {
+	Template    interface{}            `json:"template"`
+	Links       map[string]interface{} `json:"links"`
+	LinkTargets []string               `json:"linkTargets,omitempty"`
+}
+
+// cache is an on-disk, content-addressed store of parsed node results. A
+// hit means the file bytes and the parser version are unchanged since the
+// last build, every link target the cached result resolved still exists
+// in the workspace, and no other workspace path has since appeared that
+// the file's own text could now resolve against, so parsing can be
+// skipped.
+type cache struct {
+	dir     string
+	enabled bool
+}
+
+func newCache(dir string, enabled bool) (*cache, error) {
+	c := &cache{dir: filepath.Join(dir, "build-cache"), enabled: enabled}
+	if enabled {
+		if err := os.MkdirAll(c.dir, 0750); err != nil {
+			return nil, fmt.Errorf("creating cache directory %q: %w", c.dir, err)
+		}
+	}
+	return c, nil
+}
+
+// key hashes the file's own contents together with the parser version, so
+// a change to either invalidates the cached entry. It deliberately does not
+// depend on any other file in the workspace: see linksStillResolve for how
+// a dependency on another file's existence is instead validated at lookup
+// time.
+func (c *cache) key(fileBytes []byte, parserVersion string) string {
+	h := sha256.New()
+	h.Write(fileBytes)
+	h.Write([]byte(parserVersion))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// path shards entries two levels deep so a single directory never holds an
+// unreasonable number of files on large workspaces.
+func (c *cache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+func (c *cache) get(key string) (cacheEntry, bool) {
+	var entry cacheEntry
+	if !c.enabled {
+		return entry, false
+	}
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return entry, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, false
+	}
+	return entry, true
+}
+
+// quotedString matches a single- or double-quoted string literal, the
+// shape a link reference takes in JSON, or else a bare token free of
+// whitespace, quotes, and YAML/JSON punctuation, the shape an unquoted
+// YAML scalar takes.
+var quotedString = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"|'((?:[^'\\]|\\.)*)'|([^\s"'{}\[\],:#]+)`)
+
+// candidateValues extracts every quoted string literal and bare token in
+// fileBytes, i.e. every value a link-resolving parser could possibly match
+// against a workspace path.
+func candidateValues(fileBytes []byte) []string {
+	matches := quotedString.FindAllSubmatch(fileBytes, -1)
+	candidates := make([]string, 0, len(matches))
+	for _, m := range matches {
+		switch {
+		case len(m[1]) > 0:
+			candidates = append(candidates, string(m[1]))
+		case len(m[2]) > 0:
+			candidates = append(candidates, string(m[2]))
+		case len(m[3]) > 0:
+			candidates = append(candidates, string(m[3]))
+		}
+	}
+	return candidates
+}
+
+// linksStillResolve reports whether entry is still valid against the
+// current workspace. Two things can make it stale: a previously-resolved
+// link target can disappear, and a workspace path that didn't exist (or
+// wasn't a link) when entry was written can appear, letting the file's own
+// text resolve against it on re-parse. The latter matters even when
+// entry.LinkTargets is empty: an entry recorded for a file with no
+// resolvable links at write time must not vacuously pass forever once a
+// workspace file it references is added.
+//
+// The second check walks the file's own candidate values against
+// fileIndex rather than the other way around (every workspace path
+// against the file's bytes), so its cost is bounded by this file's own
+// size, not by the size of the whole workspace: checking N cached files
+// against an M-file workspace is O(N) map lookups per file instead of
+// O(M) substring scans per file.
+func linksStillResolve(entry cacheEntry, fileBytes []byte, fileIndex map[string]struct{}) bool {
+	resolved := make(map[string]struct{}, len(entry.LinkTargets))
+	for _, target := range entry.LinkTargets {
+		if _, ok := fileIndex[target]; !ok {
+			return false
+		}
+		resolved[target] = struct{}{}
+	}
+
+	for _, candidate := range candidateValues(fileBytes) {
+		if _, ok := resolved[candidate]; ok {
+			continue
+		}
+		if _, ok := fileIndex[candidate]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *cache) put(key string, entry cacheEntry) error {
+	if !c.enabled {
+		return nil
+	}
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0640)
+}
@@ -0,0 +1,217 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/uor-framework/client/builder/graph"
+	"github.com/uor-framework/client/builder/parser"
+	"github.com/uor-framework/client/util/workspace"
+)
+
+// Options configures a pipeline Run.
+type Options struct {
+	// Jobs bounds the number of files parsed concurrently. A value <= 0
+	// defaults to runtime.GOMAXPROCS(0).
+	Jobs int
+	// NoCache disables the content-addressed parse cache, forcing every
+	// file to be re-parsed.
+	NoCache bool
+	// CacheDir is the directory the parse cache is persisted under.
+	CacheDir string
+}
+
+func (o Options) jobs() int {
+	if o.Jobs > 0 {
+		return o.Jobs
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+type nodeResult struct {
+	path     string
+	template interface{}
+	links    map[string]interface{}
+}
+
+// Run walks userSpace, parses and templates each file across a bounded
+// worker pool, and assembles the results into a graph. Files whose bytes
+// and parser version are unchanged from a previous run are served from the
+// on-disk cache instead of being re-parsed, unless a previously-resolved
+// link target has disappeared or a workspace path has since appeared that
+// the file's own text could now resolve against (see linksStillResolve).
+func Run(ctx context.Context, userSpace workspace.Workspace, opts Options) (*graph.Graph, error) {
+	c, err := newCache(opts.CacheDir, !opts.NoCache)
+	if err != nil {
+		return nil, err
+	}
+
+	// Walker stage: collect the file index up front so link resolution
+	// (tFunc, below) is stable before any parsing begins.
+	var paths []string
+	err = userSpace.Walk(func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("traversing %s: %v", path, err)
+		}
+		if info == nil {
+			return fmt.Errorf("no file info")
+		}
+		if info.IsDir() {
+			return nil
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fileIndex := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		fileIndex[path] = struct{}{}
+	}
+
+	// tFunc determines whether a templated value refers to another file in
+	// the workspace.
+	tFunc := func(value interface{}) bool {
+		stringValue, ok := value.(string)
+		if !ok {
+			return false
+		}
+		_, found := fileIndex[stringValue]
+		return found
+	}
+
+	in := make(chan string)
+	out := make(chan nodeResult)
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	// Worker pool stage: parse/template files in parallel.
+	for i := 0; i < opts.jobs(); i++ {
+		g.Go(func() error {
+			for path := range in {
+				result, err := parseFile(gctx, userSpace, c, path, tFunc, fileIndex)
+				if err != nil {
+					return fmt.Errorf("parsing %s: %w", path, err)
+				}
+				select {
+				case out <- result:
+				case <-gctx.Done():
+					return gctx.Err()
+				}
+			}
+			return nil
+		})
+	}
+
+	g.Go(func() error {
+		defer close(in)
+		for _, path := range paths {
+			select {
+			case in <- path:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+		return nil
+	})
+
+	// Graph-assembly stage: a single consumer streams nodes and their
+	// edges into the graph as worker results arrive, so it never races
+	// with the pool.
+	built := graph.NewGraph()
+	var assembleErr error
+	assembleDone := make(chan struct{})
+	go func() {
+		defer close(assembleDone)
+		for result := range out {
+			node := graph.NewNode(result.path)
+			node.Template = result.template
+			node.Links = result.links
+			built.Nodes[node.Name] = node
+
+			for link, data := range result.links {
+				fpath, ok := data.(string)
+				if !ok {
+					assembleErr = fmt.Errorf("link %q: value should be of type string", link)
+					continue
+				}
+				if err := built.AddEdge(node.Name, fpath); err != nil {
+					assembleErr = err
+				}
+			}
+		}
+	}()
+
+	werr := g.Wait()
+	close(out)
+	<-assembleDone
+
+	if werr != nil {
+		return nil, werr
+	}
+	if assembleErr != nil {
+		return nil, assembleErr
+	}
+	return built, nil
+}
+
+// parseFile reads path from userSpace and returns its templated data and
+// links, serving the result from cache when possible.
+func parseFile(ctx context.Context, userSpace workspace.Workspace, c *cache, path string, tFunc func(interface{}) bool, fileIndex map[string]struct{}) (nodeResult, error) {
+	buf := new(bytes.Buffer)
+	if err := userSpace.ReadObject(ctx, path, buf); err != nil {
+		return nodeResult{}, err
+	}
+	fileBytes := buf.Bytes()
+
+	key := c.key(fileBytes, parser.Version)
+	if entry, ok := c.get(key); ok && linksStillResolve(entry, fileBytes, fileIndex) {
+		return nodeResult{path: path, template: entry.Template, links: entry.Links}, nil
+	}
+
+	perr := &parser.ErrInvalidFormat{}
+	p, err := parser.ByContentType(path, fileBytes)
+	switch {
+	case err == nil:
+		p.AddFuncs(tFunc)
+		template, links, err := p.GetLinkableData(fileBytes)
+		if err != nil {
+			return nodeResult{}, err
+		}
+		if err := c.put(key, cacheEntry{Template: template, Links: links, LinkTargets: linkTargets(links)}); err != nil {
+			return nodeResult{}, err
+		}
+		return nodeResult{path: path, template: template, links: links}, nil
+	case !errors.As(err, &perr):
+		return nodeResult{}, err
+	default:
+		// Not a templatable file. Cache the empty result too, so the next
+		// run doesn't re-attempt parsing it.
+		if err := c.put(key, cacheEntry{}); err != nil {
+			return nodeResult{}, err
+		}
+		return nodeResult{path: path}, nil
+	}
+}
+
+// linkTargets extracts the workspace paths links resolved against, sorted
+// for a stable cacheEntry encoding.
+func linkTargets(links map[string]interface{}) []string {
+	targets := make([]string, 0, len(links))
+	for _, data := range links {
+		if fpath, ok := data.(string); ok {
+			targets = append(targets, fpath)
+		}
+	}
+	sort.Strings(targets)
+	return targets
+}
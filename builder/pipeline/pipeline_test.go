@@ -0,0 +1,103 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uor-framework/client/builder/parser"
+)
+
+// TestRun_CacheHit asserts that running the pipeline a second time against
+// an unchanged workspace, with the cache populated by the first run, still
+// produces a graph holding every one of the workspace's files.
+func TestRun_CacheHit(t *testing.T) {
+	ws := newFakeWorkspace(5)
+	opts := Options{CacheDir: t.TempDir()}
+
+	_, err := Run(context.Background(), ws, opts)
+	require.NoError(t, err)
+
+	g, err := Run(context.Background(), ws, opts)
+	require.NoError(t, err)
+	require.Len(t, g.Nodes, 5)
+}
+
+// TestRun_UnrelatedFileAddDoesNotInvalidateCache is a regression test for a
+// cache key that used to fold in the entire sorted workspace file list:
+// adding an unrelated file anywhere in the workspace must not force an
+// unchanged file's cache entry to be rebuilt.
+func TestRun_UnrelatedFileAddDoesNotInvalidateCache(t *testing.T) {
+	files := map[string][]byte{
+		"a.json": []byte(`{"value":"unchanged"}`),
+	}
+	cacheDir := t.TempDir()
+
+	_, err := Run(context.Background(), &fakeWorkspace{files: files}, Options{CacheDir: cacheDir})
+	require.NoError(t, err)
+
+	c, err := newCache(cacheDir, true)
+	require.NoError(t, err)
+
+	key := c.key(files["a.json"], parser.Version)
+	_, hit := c.get(key)
+	require.True(t, hit, "a.json should have a cache entry after the first run")
+
+	files["b.json"] = []byte(`{"value":"also unchanged"}`)
+	_, err = Run(context.Background(), &fakeWorkspace{files: files}, Options{CacheDir: cacheDir})
+	require.NoError(t, err)
+
+	_, hit = c.get(key)
+	require.True(t, hit, "a.json's cache entry must survive an unrelated file being added")
+}
+
+// TestLinksStillResolve exercises the staleness check a cache hit is gated
+// on: a cached entry whose link target has since disappeared from the
+// workspace must not be reported as still resolving, and neither must an
+// entry whose raw file text now matches a workspace path it didn't
+// resolve against when it was written — even one recorded with no links
+// at all, which must not vacuously pass forever.
+func TestLinksStillResolve(t *testing.T) {
+	fileIndex := map[string]struct{}{"a.json": {}}
+
+	require.True(t, linksStillResolve(cacheEntry{LinkTargets: []string{"a.json"}}, []byte(`{}`), fileIndex))
+	require.False(t, linksStillResolve(cacheEntry{LinkTargets: []string{"missing.json"}}, []byte(`{}`), fileIndex))
+	require.True(t, linksStillResolve(cacheEntry{}, []byte(`{"value":"unrelated"}`), fileIndex))
+	require.False(t, linksStillResolve(cacheEntry{}, []byte(`{"value":"a.json"}`), fileIndex))
+}
+
+// TestRun_NewlyAddedLinkTargetInvalidatesCache is a regression test for a
+// cache entry recorded when a templated reference's target didn't exist
+// yet: LinkTargets is empty at write time, so the original staleness check
+// passed vacuously forever. Once the target file is added to the
+// workspace, the referencing file must be re-parsed so the link is picked
+// up rather than continuing to serve the linkless cached result.
+func TestRun_NewlyAddedLinkTargetInvalidatesCache(t *testing.T) {
+	files := map[string][]byte{
+		"a.json": []byte(`{"ref":"b.json"}`),
+	}
+	cacheDir := t.TempDir()
+
+	g, err := Run(context.Background(), &fakeWorkspace{files: files}, Options{CacheDir: cacheDir})
+	require.NoError(t, err)
+	require.Empty(t, g.Nodes["a.json"].Links, "b.json doesn't exist yet, so ref can't resolve to a link")
+
+	files["b.json"] = []byte(`{"value":"target"}`)
+	g, err = Run(context.Background(), &fakeWorkspace{files: files}, Options{CacheDir: cacheDir})
+	require.NoError(t, err)
+	require.Equal(t, "b.json", g.Nodes["a.json"].Links["ref"], "a.json must be re-parsed now that b.json exists")
+}
+
+// TestRun_PropagatesWorkerError asserts that a file which fails to parse
+// cancels the run and surfaces the error, rather than hanging or being
+// silently dropped.
+func TestRun_PropagatesWorkerError(t *testing.T) {
+	ws := &fakeWorkspace{files: map[string][]byte{
+		"bad.json":  []byte(`{not valid json`),
+		"good.json": []byte(`{"value":"unchanged"}`),
+	}}
+
+	_, err := Run(context.Background(), ws, Options{CacheDir: t.TempDir(), NoCache: true})
+	require.Error(t, err)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/uor-framework/client/registryclient/orasclient"
+)
+
+type BlobOptions struct {
+	*RootOptions
+	Reference string
+	Digest    string
+	Insecure  bool
+	PlainHTTP bool
+	Configs   []string
+}
+
+var clientBlobExamples = templates.Examples(
+	`
+	# Print the contents of a blob by digest
+	client blob localhost:5000/myartifacts:latest sha256:abcdef...
+	`,
+)
+
+func NewBlobCmd(rootOpts *RootOptions) *cobra.Command {
+	o := BlobOptions{RootOptions: rootOpts}
+
+	cmd := &cobra.Command{
+		Use:           "blob REFERENCE DIGEST",
+		Short:         "Print the contents of a blob from a collection",
+		Example:       clientBlobExamples,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+		Args:          cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(o.Complete(args))
+			cobra.CheckErr(o.Validate())
+			cobra.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&o.Configs, "configs", "c", o.Configs, "auth config paths")
+	cmd.Flags().BoolVarP(&o.Insecure, "insecure", "", o.Insecure, "allow connections to SSL registry without certs")
+	cmd.Flags().BoolVarP(&o.PlainHTTP, "plain-http", "", o.PlainHTTP, "use plain http and not https")
+
+	return cmd
+}
+
+func (o *BlobOptions) Complete(args []string) error {
+	if len(args) < 2 {
+		return errors.New("bug: expecting two arguments")
+	}
+	o.Reference = args[0]
+	o.Digest = args[1]
+	return nil
+}
+
+func (o *BlobOptions) Validate() error {
+	if o.Digest == "" {
+		return errors.New("digest must be set")
+	}
+	// TODO(jpower432): Validate the reference
+	return nil
+}
+
+func (o *BlobOptions) Run(ctx context.Context) error {
+	client, err := orasclient.NewClient(
+		o.Reference,
+		orasclient.SkipTLSVerify(o.Insecure),
+		orasclient.WithPlainHTTP(o.PlainHTTP),
+		orasclient.WithAuthConfigs(o.Configs),
+	)
+	if err != nil {
+		return fmt.Errorf("error configuring client: %v", err)
+	}
+
+	blob, err := client.FetchBlob(ctx, o.Digest)
+	if err != nil {
+		return fmt.Errorf("error fetching blob %s from %s: %v", o.Digest, o.Reference, err)
+	}
+
+	_, err = o.IOStreams.Out.Write(blob)
+	return err
+}
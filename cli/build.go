@@ -1,31 +1,37 @@
 package cli
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"k8s.io/kubectl/pkg/util/templates"
 
 	"github.com/uor-framework/client/builder"
-	"github.com/uor-framework/client/builder/graph"
+	"github.com/uor-framework/client/builder/config"
 	"github.com/uor-framework/client/builder/parser"
+	"github.com/uor-framework/client/builder/pipeline"
 	"github.com/uor-framework/client/registryclient/orasclient"
 	"github.com/uor-framework/client/util/workspace"
 )
 
 type BuildOptions struct {
 	*RootOptions
-	Destination string
-	RootDir     string
-	Insecure    bool
-	PlainHTTP   bool
-	Configs     []string
-	Output      string
-	Push        bool
+	Destination   string
+	RootDir       string
+	Insecure      bool
+	PlainHTTP     bool
+	Configs       []string
+	DatasetConfig string
+	Output        string
+	Push          bool
+	Plugins       []string
+	CacheDir      string
+	Jobs          int
+	NoCache       bool
 }
 
 var clientBuildExamples = templates.Examples(
@@ -61,6 +67,11 @@ func NewBuildCmd(rootOpts *RootOptions) *cobra.Command {
 	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, "location to stored templated files")
 	cmd.Flags().BoolVarP(&o.Push, "push", "p", o.Push, "push workspace artifacts to registry")
 	cmd.Flags().StringVarP(&o.Destination, "destination", "d", o.Destination, "image location to store artifacts in a registry")
+	cmd.Flags().StringArrayVarP(&o.Plugins, "plugin", "", o.Plugins, "OCI reference of a parser plugin to load before templating")
+	cmd.Flags().StringVarP(&o.DatasetConfig, "dataset-config", "", o.DatasetConfig, "path to a dataset config (YAML, JSON, or CUE) declaring parser plugins to load")
+	cmd.Flags().StringVarP(&o.CacheDir, "cache-dir", "", o.CacheDir, "directory to cache downloaded parser plugins and parsed file results in")
+	cmd.Flags().IntVarP(&o.Jobs, "jobs", "j", o.Jobs, "number of files to parse concurrently (default: number of CPUs)")
+	cmd.Flags().BoolVarP(&o.NoCache, "no-cache", "", o.NoCache, "re-parse every file instead of reusing cached results")
 
 	return cmd
 }
@@ -73,6 +84,13 @@ func (o *BuildOptions) Complete(args []string) error {
 	if o.Output == "" {
 		o.Output = "client-workspace"
 	}
+	if o.CacheDir == "" {
+		cacheHome, err := os.UserCacheDir()
+		if err != nil {
+			return err
+		}
+		o.CacheDir = filepath.Join(cacheHome, "emporous")
+	}
 	return nil
 }
 
@@ -92,88 +110,36 @@ func (o *BuildOptions) Validate() error {
 
 func (o *BuildOptions) Run(ctx context.Context) error {
 	_, _ = fmt.Fprintf(o.IOStreams.Out, "Using output directory %q\n", o.Output)
-	userSpace, err := workspace.NewLocalWorkspace(o.RootDir)
-	if err != nil {
-		return err
-	}
 
-	g := graph.NewGraph()
-
-	fileIndex := make(map[string]struct{})
-	// Do the initial walk to get an index of what is in the workspace
-	err = userSpace.Walk(func(path string, info os.FileInfo, err error) error {
+	sources := make([]parser.PluginSource, 0, len(o.Plugins))
+	for _, p := range o.Plugins {
+		sources = append(sources, parser.PluginSource{Source: p})
+	}
+	if o.DatasetConfig != "" {
+		cfg, err := config.ReadConfig(o.DatasetConfig)
 		if err != nil {
-			return fmt.Errorf("traversing %s: %v", path, err)
+			return fmt.Errorf("error reading dataset config %q: %v", o.DatasetConfig, err)
 		}
-		if info == nil {
-			return fmt.Errorf("no file info")
-		}
-
-		if info.IsDir() {
-			return nil
-		}
-
-		fileIndex[path] = struct{}{}
-
-		return nil
-	})
-	if err != nil {
-		return err
+		sources = append(sources, cfg.Plugins...)
 	}
-
-	// Function to determine whether the
-	// data should be replaced in the template
-	tFunc := func(value interface{}) bool {
-		stringValue, ok := value.(string)
-		if !ok {
-			return false
+	if len(sources) > 0 {
+		if err := parser.LoadPlugins(ctx, sources, o.CacheDir); err != nil {
+			return fmt.Errorf("error loading parser plugins: %v", err)
 		}
-
-		// If the file is found in the workspace
-		// return true
-		_, found := fileIndex[stringValue]
-		return found
 	}
 
-	for path := range fileIndex {
-		_, _ = fmt.Fprintf(o.IOStreams.Out, "Adding node %s\n", path)
-		node := graph.NewNode(path)
-
-		perr := &parser.ErrInvalidFormat{}
-		buf := new(bytes.Buffer)
-		if err := userSpace.ReadObject(ctx, path, buf); err != nil {
-			return err
-		}
-		p, err := parser.ByContentType(path, buf.Bytes())
-		switch {
-		case err == nil:
-			p.AddFuncs(tFunc)
-			node.Template, node.Links, err = p.GetLinkableData(buf.Bytes())
-			if err != nil {
-				return err
-			}
-		case !errors.As(err, &perr):
-			return err
-		}
-
-		g.Nodes[node.Name] = node
+	userSpace, err := workspace.NewLocalWorkspace(o.RootDir)
+	if err != nil {
+		return err
 	}
 
-	for _, node := range g.Nodes {
-		for link, data := range node.Links {
-			// Currently with the parsing implementation
-			// all initial values are expected to represent
-			// the file string data present in the content.
-			// FIXME(jpower432): Making this assumption could lead
-			// to bug when trying to translate links to a graph.
-			fpath, ok := data.(string)
-			if !ok {
-				return fmt.Errorf("link %q: value should be of type string", link)
-			}
-			if err := g.AddEdge(node.Name, fpath); err != nil {
-				return err
-			}
-		}
+	g, err := pipeline.Run(ctx, userSpace, pipeline.Options{
+		Jobs:     o.Jobs,
+		NoCache:  o.NoCache,
+		CacheDir: o.CacheDir,
+	})
+	if err != nil {
+		return fmt.Errorf("error parsing workspace %q: %v", o.RootDir, err)
 	}
 
 	renderSpace, err := workspace.NewLocalWorkspace(o.Output)
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/uor-framework/client/registryclient/orasclient"
+)
+
+type CatalogOptions struct {
+	*RootOptions
+	Registry  string
+	Insecure  bool
+	PlainHTTP bool
+	Configs   []string
+}
+
+var clientCatalogExamples = templates.Examples(
+	`
+	# List the repositories available in a registry as JSON
+	client catalog localhost:5000
+	`,
+)
+
+func NewCatalogCmd(rootOpts *RootOptions) *cobra.Command {
+	o := CatalogOptions{RootOptions: rootOpts}
+
+	cmd := &cobra.Command{
+		Use:           "catalog REGISTRY",
+		Short:         "Print the repository catalog of a registry as JSON",
+		Example:       clientCatalogExamples,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+		Args:          cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(o.Complete(args))
+			cobra.CheckErr(o.Validate())
+			cobra.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&o.Configs, "configs", "c", o.Configs, "auth config paths")
+	cmd.Flags().BoolVarP(&o.Insecure, "insecure", "", o.Insecure, "allow connections to SSL registry without certs")
+	cmd.Flags().BoolVarP(&o.PlainHTTP, "plain-http", "", o.PlainHTTP, "use plain http and not https")
+
+	return cmd
+}
+
+func (o *CatalogOptions) Complete(args []string) error {
+	if len(args) < 1 {
+		return errors.New("bug: expecting one argument")
+	}
+	o.Registry = args[0]
+	return nil
+}
+
+func (o *CatalogOptions) Validate() error {
+	// TODO(jpower432): Validate the registry reference
+	return nil
+}
+
+func (o *CatalogOptions) Run(ctx context.Context) error {
+	client, err := orasclient.NewClient(
+		o.Registry,
+		orasclient.SkipTLSVerify(o.Insecure),
+		orasclient.WithPlainHTTP(o.PlainHTTP),
+		orasclient.WithAuthConfigs(o.Configs),
+	)
+	if err != nil {
+		return fmt.Errorf("error configuring client: %v", err)
+	}
+
+	repos, err := client.Catalog(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing catalog for %s: %v", o.Registry, err)
+	}
+
+	enc := json.NewEncoder(o.IOStreams.Out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(repos)
+}
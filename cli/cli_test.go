@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogOptions_Complete(t *testing.T) {
+	o := CatalogOptions{}
+	require.NoError(t, o.Complete([]string{"localhost:5000"}))
+	require.Equal(t, "localhost:5000", o.Registry)
+	require.Error(t, o.Complete(nil))
+}
+
+func TestDeleteOptions_Complete(t *testing.T) {
+	o := DeleteOptions{}
+	require.NoError(t, o.Complete([]string{"localhost:5000/repo:tag"}))
+	require.Equal(t, "localhost:5000/repo:tag", o.Reference)
+	require.Error(t, o.Complete(nil))
+}
+
+func TestDigestOptions_Complete(t *testing.T) {
+	o := DigestOptions{}
+	require.NoError(t, o.Complete([]string{"localhost:5000/repo:tag"}))
+	require.Equal(t, "localhost:5000/repo:tag", o.Reference)
+	require.Error(t, o.Complete(nil))
+}
+
+func TestManifestOptions_Complete(t *testing.T) {
+	o := ManifestOptions{}
+	require.NoError(t, o.Complete([]string{"localhost:5000/repo:tag"}))
+	require.Equal(t, "localhost:5000/repo:tag", o.Reference)
+	require.Error(t, o.Complete(nil))
+}
+
+func TestBlobOptions_Complete(t *testing.T) {
+	o := BlobOptions{}
+	require.NoError(t, o.Complete([]string{"localhost:5000/repo:tag", "sha256:abcdef"}))
+	require.Equal(t, "localhost:5000/repo:tag", o.Reference)
+	require.Equal(t, "sha256:abcdef", o.Digest)
+	require.Error(t, o.Complete([]string{"localhost:5000/repo:tag"}))
+}
+
+func TestBlobOptions_Validate(t *testing.T) {
+	o := BlobOptions{Reference: "localhost:5000/repo:tag", Digest: "sha256:abcdef"}
+	require.NoError(t, o.Validate())
+
+	o = BlobOptions{Reference: "localhost:5000/repo:tag"}
+	require.Error(t, o.Validate())
+}
+
+func TestCopyOptions_Complete(t *testing.T) {
+	o := CopyOptions{}
+	require.NoError(t, o.Complete([]string{"src:tag", "dst:tag"}))
+	require.Equal(t, "src:tag", o.Source)
+	require.Equal(t, "dst:tag", o.Destination)
+	require.Error(t, o.Complete([]string{"src:tag"}))
+}
+
+func TestCopyOptions_Validate(t *testing.T) {
+	o := CopyOptions{Source: "same:tag", Destination: "same:tag"}
+	require.Error(t, o.Validate())
+
+	o = CopyOptions{Source: "src:tag", Destination: "dst:tag"}
+	require.NoError(t, o.Validate())
+}
+
+func TestFlattenOptions_Complete(t *testing.T) {
+	o := FlattenOptions{}
+	require.NoError(t, o.Complete([]string{"src:tag", "dst:tag"}))
+	require.Equal(t, "src:tag", o.Source)
+	require.Equal(t, "dst:tag", o.Destination)
+	require.Error(t, o.Complete([]string{"src:tag"}))
+}
+
+func TestVerifyOptions_Complete(t *testing.T) {
+	o := VerifyOptions{}
+	require.NoError(t, o.Complete([]string{"localhost:5000/repo:tag"}))
+	require.Equal(t, "localhost:5000/repo:tag", o.Reference)
+	require.Error(t, o.Complete(nil))
+}
+
+func TestVerifyOptions_Validate(t *testing.T) {
+	require.Error(t, (&VerifyOptions{}).Validate(), "one of --cosign-key or --keyless is required")
+	require.Error(t, (&VerifyOptions{Keyless: true, CosignKey: "cosign.pub"}).Validate())
+	require.NoError(t, (&VerifyOptions{CosignKey: "cosign.pub"}).Validate())
+	require.Error(t, (&VerifyOptions{Keyless: true}).Validate(), "fulcio-root must be set when using --keyless")
+	require.NoError(t, (&VerifyOptions{Keyless: true, FulcioRoot: "fulcio-root.pem"}).Validate())
+}
+
+func TestPublishOptions_Validate(t *testing.T) {
+	o := PublishOptions{BuildOptions: &BuildOptions{RootDir: "."}, Sign: true, Keyless: true}
+	require.Error(t, o.Validate(), "only one of --sign or --keyless may be set")
+
+	o = PublishOptions{BuildOptions: &BuildOptions{RootDir: "."}, Sign: true}
+	require.Error(t, o.Validate(), "cosign-key is required when --sign is set")
+
+	o = PublishOptions{BuildOptions: &BuildOptions{RootDir: "."}, Sign: true, CosignKey: "cosign.key"}
+	require.NoError(t, o.Validate())
+}
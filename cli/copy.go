@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/uor-framework/client/registryclient/orasclient"
+)
+
+type CopyOptions struct {
+	*RootOptions
+	Source      string
+	Destination string
+	Insecure    bool
+	PlainHTTP   bool
+	Configs     []string
+}
+
+var clientCopyExamples = templates.Examples(
+	`
+	# Copy a collection directly between two registry locations
+	client copy localhost:5000/myartifacts:latest localhost:5001/myartifacts:latest
+	`,
+)
+
+func NewCopyCmd(rootOpts *RootOptions) *cobra.Command {
+	o := CopyOptions{RootOptions: rootOpts}
+
+	cmd := &cobra.Command{
+		Use:           "copy SRC DST",
+		Short:         "Copy an Emporous collection between registries without local storage",
+		Example:       clientCopyExamples,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+		Args:          cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(o.Complete(args))
+			cobra.CheckErr(o.Validate())
+			cobra.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&o.Configs, "configs", "c", o.Configs, "auth config paths")
+	cmd.Flags().BoolVarP(&o.Insecure, "insecure", "", o.Insecure, "allow connections to SSL registry without certs")
+	cmd.Flags().BoolVarP(&o.PlainHTTP, "plain-http", "", o.PlainHTTP, "use plain http and not https")
+
+	return cmd
+}
+
+func (o *CopyOptions) Complete(args []string) error {
+	if len(args) < 2 {
+		return errors.New("bug: expecting two arguments")
+	}
+	o.Source = args[0]
+	o.Destination = args[1]
+	return nil
+}
+
+func (o *CopyOptions) Validate() error {
+	if o.Source == o.Destination {
+		return errors.New("source and destination must differ")
+	}
+	// TODO(jpower432): Validate the references
+	return nil
+}
+
+func (o *CopyOptions) Run(ctx context.Context) error {
+	client, err := orasclient.NewClient(
+		o.Source,
+		orasclient.SkipTLSVerify(o.Insecure),
+		orasclient.WithPlainHTTP(o.PlainHTTP),
+		orasclient.WithAuthConfigs(o.Configs),
+	)
+	if err != nil {
+		return fmt.Errorf("error configuring client: %v", err)
+	}
+
+	// Copy streams the content graph directly from the source store to the
+	// destination store, so artifacts are never materialized to disk.
+	if err := client.Copy(ctx, o.Destination); err != nil {
+		return fmt.Errorf("error copying %s to %s: %v", o.Source, o.Destination, err)
+	}
+
+	_, _ = fmt.Fprintf(o.IOStreams.Out, "Copied %s to %s\n", o.Source, o.Destination)
+	return nil
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/uor-framework/client/registryclient/orasclient"
+)
+
+type DeleteOptions struct {
+	*RootOptions
+	Reference string
+	Insecure  bool
+	PlainHTTP bool
+	Configs   []string
+}
+
+var clientDeleteExamples = templates.Examples(
+	`
+	# Delete a tag or manifest from a registry
+	client delete localhost:5000/myartifacts:latest
+	`,
+)
+
+func NewDeleteCmd(rootOpts *RootOptions) *cobra.Command {
+	o := DeleteOptions{RootOptions: rootOpts}
+
+	cmd := &cobra.Command{
+		Use:           "delete REFERENCE",
+		Short:         "Delete an Emporous collection from a registry",
+		Example:       clientDeleteExamples,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+		Args:          cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(o.Complete(args))
+			cobra.CheckErr(o.Validate())
+			cobra.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&o.Configs, "configs", "c", o.Configs, "auth config paths")
+	cmd.Flags().BoolVarP(&o.Insecure, "insecure", "", o.Insecure, "allow connections to SSL registry without certs")
+	cmd.Flags().BoolVarP(&o.PlainHTTP, "plain-http", "", o.PlainHTTP, "use plain http and not https")
+
+	return cmd
+}
+
+func (o *DeleteOptions) Complete(args []string) error {
+	if len(args) < 1 {
+		return errors.New("bug: expecting one argument")
+	}
+	o.Reference = args[0]
+	return nil
+}
+
+func (o *DeleteOptions) Validate() error {
+	// TODO(jpower432): Validate the reference
+	return nil
+}
+
+func (o *DeleteOptions) Run(ctx context.Context) error {
+	client, err := orasclient.NewClient(
+		o.Reference,
+		orasclient.SkipTLSVerify(o.Insecure),
+		orasclient.WithPlainHTTP(o.PlainHTTP),
+		orasclient.WithAuthConfigs(o.Configs),
+	)
+	if err != nil {
+		return fmt.Errorf("error configuring client: %v", err)
+	}
+
+	if err := client.Delete(ctx); err != nil {
+		return fmt.Errorf("error deleting %s: %v", o.Reference, err)
+	}
+
+	_, _ = fmt.Fprintf(o.IOStreams.Out, "Deleted %s\n", o.Reference)
+	return nil
+}
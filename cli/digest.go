@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/uor-framework/client/registryclient/orasclient"
+)
+
+type DigestOptions struct {
+	*RootOptions
+	Reference string
+	Insecure  bool
+	PlainHTTP bool
+	Configs   []string
+}
+
+var clientDigestExamples = templates.Examples(
+	`
+	# Print the manifest digest for a reference
+	client digest localhost:5000/myartifacts:latest
+	`,
+)
+
+func NewDigestCmd(rootOpts *RootOptions) *cobra.Command {
+	o := DigestOptions{RootOptions: rootOpts}
+
+	cmd := &cobra.Command{
+		Use:           "digest REFERENCE",
+		Short:         "Print the manifest digest of an Emporous collection",
+		Example:       clientDigestExamples,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+		Args:          cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(o.Complete(args))
+			cobra.CheckErr(o.Validate())
+			cobra.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&o.Configs, "configs", "c", o.Configs, "auth config paths")
+	cmd.Flags().BoolVarP(&o.Insecure, "insecure", "", o.Insecure, "allow connections to SSL registry without certs")
+	cmd.Flags().BoolVarP(&o.PlainHTTP, "plain-http", "", o.PlainHTTP, "use plain http and not https")
+
+	return cmd
+}
+
+func (o *DigestOptions) Complete(args []string) error {
+	if len(args) < 1 {
+		return errors.New("bug: expecting one argument")
+	}
+	o.Reference = args[0]
+	return nil
+}
+
+func (o *DigestOptions) Validate() error {
+	// TODO(jpower432): Validate the reference
+	return nil
+}
+
+func (o *DigestOptions) Run(ctx context.Context) error {
+	client, err := orasclient.NewClient(
+		o.Reference,
+		orasclient.SkipTLSVerify(o.Insecure),
+		orasclient.WithPlainHTTP(o.PlainHTTP),
+		orasclient.WithAuthConfigs(o.Configs),
+	)
+	if err != nil {
+		return fmt.Errorf("error configuring client: %v", err)
+	}
+
+	digest, err := client.Digest(ctx)
+	if err != nil {
+		return fmt.Errorf("error resolving digest for %s: %v", o.Reference, err)
+	}
+
+	_, _ = fmt.Fprintln(o.IOStreams.Out, digest)
+	return nil
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/uor-framework/client/registryclient/orasclient"
+)
+
+type FlattenOptions struct {
+	*RootOptions
+	Source      string
+	Destination string
+	Insecure    bool
+	PlainHTTP   bool
+	Configs     []string
+}
+
+var clientFlattenExamples = templates.Examples(
+	`
+	# Collapse a multi-layer collection into a single-layer artifact
+	client flatten localhost:5000/myartifacts:latest localhost:5000/myartifacts:flat
+	`,
+)
+
+func NewFlattenCmd(rootOpts *RootOptions) *cobra.Command {
+	o := FlattenOptions{RootOptions: rootOpts}
+
+	cmd := &cobra.Command{
+		Use:           "flatten SRC DST",
+		Short:         "Collapse a multi-layer Emporous collection into a single layer",
+		Example:       clientFlattenExamples,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+		Args:          cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(o.Complete(args))
+			cobra.CheckErr(o.Validate())
+			cobra.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&o.Configs, "configs", "c", o.Configs, "auth config paths")
+	cmd.Flags().BoolVarP(&o.Insecure, "insecure", "", o.Insecure, "allow connections to SSL registry without certs")
+	cmd.Flags().BoolVarP(&o.PlainHTTP, "plain-http", "", o.PlainHTTP, "use plain http and not https")
+
+	return cmd
+}
+
+func (o *FlattenOptions) Complete(args []string) error {
+	if len(args) < 2 {
+		return errors.New("bug: expecting two arguments")
+	}
+	o.Source = args[0]
+	o.Destination = args[1]
+	return nil
+}
+
+func (o *FlattenOptions) Validate() error {
+	// TODO(jpower432): Validate the references
+	return nil
+}
+
+func (o *FlattenOptions) Run(ctx context.Context) error {
+	client, err := orasclient.NewClient(
+		o.Source,
+		orasclient.SkipTLSVerify(o.Insecure),
+		orasclient.WithPlainHTTP(o.PlainHTTP),
+		orasclient.WithAuthConfigs(o.Configs),
+	)
+	if err != nil {
+		return fmt.Errorf("error configuring client: %v", err)
+	}
+
+	// Flatten merges all layer blobs for the source collection into a single
+	// layer while preserving the attribute schemas stored in the manifest
+	// and config annotations, then pushes the result to the destination.
+	if err := client.Flatten(ctx, o.Destination); err != nil {
+		return fmt.Errorf("error flattening %s to %s: %v", o.Source, o.Destination, err)
+	}
+
+	_, _ = fmt.Fprintf(o.IOStreams.Out, "Flattened %s to %s\n", o.Source, o.Destination)
+	return nil
+}
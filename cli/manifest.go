@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/uor-framework/client/registryclient/orasclient"
+)
+
+type ManifestOptions struct {
+	*RootOptions
+	Reference string
+	Insecure  bool
+	PlainHTTP bool
+	Configs   []string
+}
+
+var clientManifestExamples = templates.Examples(
+	`
+	# Print the manifest for a reference as JSON
+	client manifest localhost:5000/myartifacts:latest
+	`,
+)
+
+func NewManifestCmd(rootOpts *RootOptions) *cobra.Command {
+	o := ManifestOptions{RootOptions: rootOpts}
+
+	cmd := &cobra.Command{
+		Use:           "manifest REFERENCE",
+		Short:         "Print the manifest of an Emporous collection as JSON",
+		Example:       clientManifestExamples,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+		Args:          cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(o.Complete(args))
+			cobra.CheckErr(o.Validate())
+			cobra.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&o.Configs, "configs", "c", o.Configs, "auth config paths")
+	cmd.Flags().BoolVarP(&o.Insecure, "insecure", "", o.Insecure, "allow connections to SSL registry without certs")
+	cmd.Flags().BoolVarP(&o.PlainHTTP, "plain-http", "", o.PlainHTTP, "use plain http and not https")
+
+	return cmd
+}
+
+func (o *ManifestOptions) Complete(args []string) error {
+	if len(args) < 1 {
+		return errors.New("bug: expecting one argument")
+	}
+	o.Reference = args[0]
+	return nil
+}
+
+func (o *ManifestOptions) Validate() error {
+	// TODO(jpower432): Validate the reference
+	return nil
+}
+
+func (o *ManifestOptions) Run(ctx context.Context) error {
+	client, err := orasclient.NewClient(
+		o.Reference,
+		orasclient.SkipTLSVerify(o.Insecure),
+		orasclient.WithPlainHTTP(o.PlainHTTP),
+		orasclient.WithAuthConfigs(o.Configs),
+	)
+	if err != nil {
+		return fmt.Errorf("error configuring client: %v", err)
+	}
+
+	manifest, err := client.FetchManifest(ctx)
+	if err != nil {
+		return fmt.Errorf("error fetching manifest for %s: %v", o.Reference, err)
+	}
+
+	_, err = o.IOStreams.Out.Write(manifest)
+	return err
+}
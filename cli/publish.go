@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/uor-framework/client/registryclient/orasclient"
+	"github.com/uor-framework/client/sign"
+)
+
+type PublishOptions struct {
+	*BuildOptions
+	Sign       bool
+	CosignKey  string
+	Keyless    bool
+	FulcioURL  string
+	RekorURL   string
+	Provenance bool
+}
+
+var clientPublishExamples = templates.Examples(
+	`
+	# Build and push a collection, signing the result with a cosign key pair
+	client publish <directory> --destination localhost:5000/myartifacts:latest --sign --cosign-key cosign.key
+
+	# Build and push a collection, signing keylessly and attaching provenance
+	client publish <directory> --destination localhost:5000/myartifacts:latest --keyless --provenance
+	`,
+)
+
+func NewPublishCmd(rootOpts *RootOptions) *cobra.Command {
+	o := PublishOptions{BuildOptions: &BuildOptions{RootOptions: rootOpts}}
+
+	cmd := &cobra.Command{
+		Use:           "publish directory",
+		Short:         "Build, push, and optionally sign and attach provenance to a collection",
+		Example:       clientPublishExamples,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+		Args:          cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(o.Complete(args))
+			cobra.CheckErr(o.Validate())
+			cobra.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&o.Configs, "configs", "c", o.Configs, "auth config paths")
+	cmd.Flags().BoolVarP(&o.Insecure, "insecure", "", o.Insecure, "allow connections to SSL registry without certs")
+	cmd.Flags().BoolVarP(&o.PlainHTTP, "plain-http", "", o.PlainHTTP, "use plain http and not https")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, "location to stored templated files")
+	cmd.Flags().StringVarP(&o.Destination, "destination", "d", o.Destination, "image location to store artifacts in a registry")
+	cmd.Flags().BoolVarP(&o.Sign, "sign", "", o.Sign, "sign the published artifact")
+	cmd.Flags().StringVarP(&o.CosignKey, "cosign-key", "", o.CosignKey, "path to a cosign key pair to sign with")
+	cmd.Flags().BoolVarP(&o.Keyless, "keyless", "", o.Keyless, "sign using the Fulcio/Rekor keyless OIDC flow")
+	cmd.Flags().StringVarP(&o.FulcioURL, "fulcio-url", "", sign.DefaultFulcioURL, "Fulcio instance to use for keyless signing")
+	cmd.Flags().StringVarP(&o.RekorURL, "rekor-url", "", sign.DefaultRekorURL, "Rekor instance to use for keyless signing")
+	cmd.Flags().BoolVarP(&o.Provenance, "provenance", "", o.Provenance, "generate and attach in-toto SLSA provenance")
+
+	return cmd
+}
+
+func (o *PublishOptions) Complete(args []string) error {
+	o.Push = true
+	if err := o.BuildOptions.Complete(args); err != nil {
+		return err
+	}
+	if o.Destination == "" {
+		return errors.New("destination must be set")
+	}
+	return nil
+}
+
+func (o *PublishOptions) Validate() error {
+	if o.Sign && o.Keyless {
+		return errors.New("only one of --sign or --keyless may be set")
+	}
+	if o.Sign && o.CosignKey == "" {
+		return errors.New("cosign-key must be set when using --sign")
+	}
+	return o.BuildOptions.Validate()
+}
+
+func (o *PublishOptions) Run(ctx context.Context) error {
+	if err := o.BuildOptions.Run(ctx); err != nil {
+		return err
+	}
+
+	client, err := orasclient.NewClient(
+		o.Destination,
+		orasclient.SkipTLSVerify(o.Insecure),
+		orasclient.WithPlainHTTP(o.PlainHTTP),
+		orasclient.WithAuthConfigs(o.Configs),
+	)
+	if err != nil {
+		return fmt.Errorf("error configuring client: %v", err)
+	}
+
+	digest, err := client.Digest(ctx)
+	if err != nil {
+		return fmt.Errorf("error resolving digest for %s: %v", o.Destination, err)
+	}
+
+	var signer sign.Signer
+	if o.Sign || o.Keyless || o.Provenance {
+		signer, err = o.newSigner(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.Sign || o.Keyless {
+		if err := o.attachSignature(ctx, client, digest, signer); err != nil {
+			return err
+		}
+	}
+
+	if o.Provenance {
+		if err := o.attachProvenance(ctx, client, digest, signer); err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprintf(o.IOStreams.Out, "Published %s (%s)\n", o.Destination, digest)
+	return nil
+}
+
+// newSigner builds the Signer implied by o.Sign/o.Keyless, defaulting to a
+// keyless signer when only --provenance was requested, since provenance
+// needs a signer regardless of whether the artifact itself is signed.
+func (o *PublishOptions) newSigner(ctx context.Context) (sign.Signer, error) {
+	switch {
+	case o.Keyless:
+		return sign.NewKeylessSigner(o.FulcioURL, o.RekorURL), nil
+	case o.Sign:
+		return sign.NewKeyPairSigner(ctx, o.CosignKey)
+	default:
+		return sign.NewKeylessSigner(o.FulcioURL, o.RekorURL), nil
+	}
+}
+
+func (o *PublishOptions) attachSignature(ctx context.Context, client *orasclient.Client, digest string, signer sign.Signer) error {
+	sig, err := signer.Sign(ctx, digest)
+	if err != nil {
+		return fmt.Errorf("error signing %s: %v", digest, err)
+	}
+
+	// Attach the full Signature, not just sig.Signature: for a keyless
+	// signature, sig.Cert and sig.Chain are what lets a verifier establish
+	// trust in the absence of a pre-shared key, and are meaningless to drop.
+	bundle, err := json.Marshal(sig)
+	if err != nil {
+		return fmt.Errorf("error encoding signature bundle for %s: %v", digest, err)
+	}
+
+	if err := client.AttachReferrer(ctx, digest, sign.ArtifactType, bundle); err != nil {
+		return fmt.Errorf("error attaching signature to %s: %v", digest, err)
+	}
+
+	_, _ = fmt.Fprintf(o.IOStreams.Out, "Signed %s\n", digest)
+	return nil
+}
+
+func (o *PublishOptions) attachProvenance(ctx context.Context, client *orasclient.Client, digest string, signer sign.Signer) error {
+	hashes := map[string]string{}
+	if o.DatasetConfig != "" {
+		data, err := os.ReadFile(o.DatasetConfig)
+		if err != nil {
+			return fmt.Errorf("reading dataset config %q: %v", o.DatasetConfig, err)
+		}
+		sum := sha256.Sum256(data)
+		hashes[o.DatasetConfig] = hex.EncodeToString(sum[:])
+	}
+
+	envelope, err := sign.GenerateProvenance(ctx, signer, digest, sign.BuildInputs{
+		WorkspacePath: o.RootDir,
+		ConfigHashes:  hashes,
+		GitCommit:     gitCommit(o.RootDir),
+	})
+	if err != nil {
+		return fmt.Errorf("error generating provenance for %s: %v", digest, err)
+	}
+
+	if err := client.AttachReferrer(ctx, digest, sign.ProvenanceArtifactType, envelope); err != nil {
+		return fmt.Errorf("error attaching provenance to %s: %v", digest, err)
+	}
+
+	_, _ = fmt.Fprintf(o.IOStreams.Out, "Attached provenance to %s\n", digest)
+	return nil
+}
+
+// gitCommit returns the current HEAD commit for dir, or an empty string if
+// dir is not part of a git repository.
+func gitCommit(dir string) string {
+	cmd := exec.Command("git", "-C", dir, "rev-parse", "HEAD")
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
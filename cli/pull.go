@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/uor-framework/client/registryclient/orasclient"
+)
+
+type PullOptions struct {
+	*RootOptions
+	Source    string
+	Output    string
+	Insecure  bool
+	PlainHTTP bool
+	Configs   []string
+}
+
+var clientPullExamples = templates.Examples(
+	`
+	# Pull a collection from a registry into a local directory
+	client pull localhost:5000/myartifacts:latest --output collection
+	`,
+)
+
+func NewPullCmd(rootOpts *RootOptions) *cobra.Command {
+	o := PullOptions{RootOptions: rootOpts}
+
+	cmd := &cobra.Command{
+		Use:           "pull SRC",
+		Short:         "Pull an Emporous collection from a registry",
+		Example:       clientPullExamples,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+		Args:          cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(o.Complete(args))
+			cobra.CheckErr(o.Validate())
+			cobra.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&o.Configs, "configs", "c", o.Configs, "auth config paths")
+	cmd.Flags().BoolVarP(&o.Insecure, "insecure", "", o.Insecure, "allow connections to SSL registry without certs")
+	cmd.Flags().BoolVarP(&o.PlainHTTP, "plain-http", "", o.PlainHTTP, "use plain http and not https")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, "directory to store pulled content")
+
+	return cmd
+}
+
+func (o *PullOptions) Complete(args []string) error {
+	if len(args) < 1 {
+		return errors.New("bug: expecting one argument")
+	}
+	o.Source = args[0]
+	if o.Output == "" {
+		o.Output = "client-workspace"
+	}
+	return nil
+}
+
+func (o *PullOptions) Validate() error {
+	// TODO(jpower432): Validate the reference
+	return nil
+}
+
+func (o *PullOptions) Run(ctx context.Context) error {
+	if err := os.MkdirAll(o.Output, 0750); err != nil {
+		return fmt.Errorf("error creating output directory %q: %v", o.Output, err)
+	}
+
+	client, err := orasclient.NewClient(
+		o.Source,
+		orasclient.SkipTLSVerify(o.Insecure),
+		orasclient.WithPlainHTTP(o.PlainHTTP),
+		orasclient.WithAuthConfigs(o.Configs),
+	)
+	if err != nil {
+		return fmt.Errorf("error configuring client: %v", err)
+	}
+
+	if err := client.Pull(ctx, o.Output); err != nil {
+		return fmt.Errorf("error pulling %s: %v", o.Source, err)
+	}
+
+	_, _ = fmt.Fprintf(o.IOStreams.Out, "Pulled %s to %q\n", o.Source, o.Output)
+	return nil
+}
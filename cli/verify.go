@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/uor-framework/client/registryclient/orasclient"
+	"github.com/uor-framework/client/sign"
+)
+
+type VerifyOptions struct {
+	*RootOptions
+	Reference  string
+	CosignKey  string
+	Keyless    bool
+	FulcioRoot string
+	Insecure   bool
+	PlainHTTP  bool
+	Configs    []string
+}
+
+var clientVerifyExamples = templates.Examples(
+	`
+	# Verify a cosign key-pair signature and provenance attached to a published collection
+	client verify localhost:5000/myartifacts:latest --cosign-key cosign.pub
+
+	# Verify a keyless Fulcio/Rekor signature attached to a published collection
+	client verify localhost:5000/myartifacts:latest --keyless
+	`,
+)
+
+func NewVerifyCmd(rootOpts *RootOptions) *cobra.Command {
+	o := VerifyOptions{RootOptions: rootOpts}
+
+	cmd := &cobra.Command{
+		Use:           "verify REFERENCE",
+		Short:         "Verify the signature and provenance referrers attached to a collection",
+		Example:       clientVerifyExamples,
+		SilenceErrors: false,
+		SilenceUsage:  false,
+		Args:          cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cobra.CheckErr(o.Complete(args))
+			cobra.CheckErr(o.Validate())
+			cobra.CheckErr(o.Run(cmd.Context()))
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&o.Configs, "configs", "c", o.Configs, "auth config paths")
+	cmd.Flags().BoolVarP(&o.Insecure, "insecure", "", o.Insecure, "allow connections to SSL registry without certs")
+	cmd.Flags().BoolVarP(&o.PlainHTTP, "plain-http", "", o.PlainHTTP, "use plain http and not https")
+	cmd.Flags().StringVarP(&o.CosignKey, "cosign-key", "", o.CosignKey, "public key to verify the signature with")
+	cmd.Flags().BoolVarP(&o.Keyless, "keyless", "", o.Keyless, "verify a keyless Fulcio/Rekor signature instead of a static key")
+	cmd.Flags().StringVarP(&o.FulcioRoot, "fulcio-root", "", o.FulcioRoot, "PEM file of the trusted Fulcio root CA(s) to verify keyless certificates against")
+
+	return cmd
+}
+
+func (o *VerifyOptions) Complete(args []string) error {
+	if len(args) < 1 {
+		return errors.New("bug: expecting one argument")
+	}
+	o.Reference = args[0]
+	return nil
+}
+
+func (o *VerifyOptions) Validate() error {
+	if o.Keyless && o.CosignKey != "" {
+		return errors.New("only one of --cosign-key or --keyless may be set")
+	}
+	if !o.Keyless && o.CosignKey == "" {
+		return errors.New("cosign-key must be set unless using --keyless")
+	}
+	if o.Keyless && o.FulcioRoot == "" {
+		// A keyless signature's certificate is only as trustworthy as the
+		// root it's checked against. Without an explicit, operator-pinned
+		// root, verification would have to trust whatever chain the
+		// registry happens to serve back, which defeats the point.
+		return errors.New("fulcio-root must be set when using --keyless")
+	}
+	return nil
+}
+
+func (o *VerifyOptions) Run(ctx context.Context) error {
+	var fulcioRoot []byte
+	if o.Keyless {
+		var err error
+		fulcioRoot, err = os.ReadFile(o.FulcioRoot)
+		if err != nil {
+			return fmt.Errorf("error reading fulcio-root %q: %v", o.FulcioRoot, err)
+		}
+	}
+
+	client, err := orasclient.NewClient(
+		o.Reference,
+		orasclient.SkipTLSVerify(o.Insecure),
+		orasclient.WithPlainHTTP(o.PlainHTTP),
+		orasclient.WithAuthConfigs(o.Configs),
+	)
+	if err != nil {
+		return fmt.Errorf("error configuring client: %v", err)
+	}
+
+	digest, err := client.Digest(ctx)
+	if err != nil {
+		return fmt.Errorf("error resolving digest for %s: %v", o.Reference, err)
+	}
+
+	referrers, err := client.Referrers(ctx, digest)
+	if err != nil {
+		return fmt.Errorf("error walking referrers of %s: %v", digest, err)
+	}
+
+	var sawSignature, sawProvenance bool
+	for _, r := range referrers {
+		switch r.ArtifactType {
+		case sign.ArtifactType:
+			var bundle sign.Signature
+			if err := json.Unmarshal(r.Content, &bundle); err != nil {
+				return fmt.Errorf("error decoding signature bundle for %s: %v", digest, err)
+			}
+			if o.Keyless {
+				if err := sign.VerifyKeylessSignature(bundle.Cert, bundle.Chain, fulcioRoot, digest, bundle.Signature, bundle.RekorIntegratedTime); err != nil {
+					return fmt.Errorf("error verifying keyless signature on %s: %v", digest, err)
+				}
+			} else {
+				if err := sign.VerifySignature(o.CosignKey, digest, bundle.Signature); err != nil {
+					return fmt.Errorf("error verifying signature on %s: %v", digest, err)
+				}
+			}
+			sawSignature = true
+		case sign.ProvenanceArtifactType:
+			verify := func(pae []byte, dsseSig sign.DSSESignature) error {
+				if o.Keyless {
+					return sign.VerifyRawKeylessSignature(dsseSig.Cert, dsseSig.Chain, fulcioRoot, pae, dsseSig.Sig, dsseSig.RekorIntegratedTime)
+				}
+				return sign.VerifyRawSignature(o.CosignKey, pae, dsseSig.Sig)
+			}
+			if err := sign.VerifyProvenance(r.Content, digest, verify); err != nil {
+				return fmt.Errorf("error verifying provenance on %s: %v", digest, err)
+			}
+			sawProvenance = true
+		}
+	}
+
+	if !sawSignature {
+		return fmt.Errorf("no signature referrer found for %s", digest)
+	}
+
+	_, _ = fmt.Fprintf(o.IOStreams.Out, "Signature verified for %s\n", digest)
+	if sawProvenance {
+		_, _ = fmt.Fprintf(o.IOStreams.Out, "Provenance present for %s\n", digest)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orasclient
+
+import (
+	"context"
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+)
+
+// credentialFunc builds an auth.CredentialFunc that consults configPaths,
+// a set of docker-config.json-style auth files, for the registry a
+// request is challenged by. Paths are consulted last first, so a later
+// path in configPaths overrides an earlier one (e.g. a user-level config
+// layered under a CI-provided one), matching docker/oras CLI precedence.
+// bearer token exchange, token caching, and the docker-config.json parsing
+// itself are all handled by oras-go's auth and credentials packages rather
+// than reimplemented here.
+func credentialFunc(configPaths []string) auth.CredentialFunc {
+	return func(ctx context.Context, registry string) (auth.Credential, error) {
+		for i := len(configPaths) - 1; i >= 0; i-- {
+			path := configPaths[i]
+
+			store, err := credentials.NewStore(path, credentials.StoreOptions{})
+			if err != nil {
+				return auth.EmptyCredential, fmt.Errorf("reading auth config %q: %w", path, err)
+			}
+
+			cred, err := store.Get(ctx, registry)
+			if err != nil {
+				return auth.EmptyCredential, fmt.Errorf("reading auth config %q: %w", path, err)
+			}
+			if cred != auth.EmptyCredential {
+				return cred, nil
+			}
+		}
+		return auth.EmptyCredential, nil
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orasclient
+
+import (
+	"context"
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// Catalog lists the repositories available in the client's registry.
+func (c *Client) Catalog(ctx context.Context) ([]string, error) {
+	reg := &remote.Registry{
+		Reference: c.repo.Reference,
+		Client:    c.repo.Client,
+		PlainHTTP: c.repo.PlainHTTP,
+	}
+	reg.Reference.Repository = ""
+
+	var repos []string
+	if err := reg.Repositories(ctx, "", func(page []string) error {
+		repos = append(repos, page...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("listing catalog: %w", err)
+	}
+	return repos, nil
+}
+
+// Delete removes the manifest at the client's reference from the
+// registry. Per the OCI Distribution Spec, a registry only supports
+// deletion by digest, so a tag reference is resolved to its digest first.
+func (c *Client) Delete(ctx context.Context) error {
+	desc, err := c.repo.Resolve(ctx, c.ref.Reference)
+	if err != nil {
+		return fmt.Errorf("resolving digest for %s: %w", c.ref, err)
+	}
+
+	if err := c.repo.Manifests().Delete(ctx, desc); err != nil {
+		return fmt.Errorf("deleting %s: %w", c.ref, err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orasclient
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// pendingManifest is the manifest staged by GenerateManifest, held until
+// Execute packs and pushes it alongside the blobs staged in store.
+type pendingManifest struct {
+	config      ocispec.Descriptor
+	layers      []ocispec.Descriptor
+	annotations map[string]string
+}
+
+// Client talks to a single registry/repository, resolved from the
+// reference passed to NewClient, through oras-go's remote.Repository. A
+// Client is built for one operation (push, pull, catalog, ...) and is not
+// meant to be reused across unrelated references.
+type Client struct {
+	ref  reference
+	repo *remote.Repository
+
+	insecure    bool
+	plainHTTP   bool
+	authConfigs []string
+
+	// store stages blobs and the manifest built up by
+	// GatherDescriptors/GenerateConfig/GenerateManifest until Execute
+	// copies them to repo in one graph walk.
+	store           *memory.Store
+	pendingManifest *pendingManifest
+}
+
+// ClientOpt configures a Client constructed by NewClient.
+type ClientOpt func(*Client)
+
+// SkipTLSVerify disables TLS certificate verification when skip is true,
+// for registries serving a self-signed or otherwise unverifiable certificate.
+func SkipTLSVerify(skip bool) ClientOpt {
+	return func(c *Client) {
+		c.insecure = skip
+	}
+}
+
+// WithPlainHTTP makes the client speak plain HTTP instead of HTTPS, for
+// registries that don't terminate TLS at all (e.g. a local dev registry).
+func WithPlainHTTP(plain bool) ClientOpt {
+	return func(c *Client) {
+		c.plainHTTP = plain
+	}
+}
+
+// WithAuthConfigs sets the docker-config.json-style auth file paths
+// consulted for registry credentials when the registry challenges a
+// request for a bearer token.
+func WithAuthConfigs(configs []string) ClientOpt {
+	return func(c *Client) {
+		c.authConfigs = configs
+	}
+}
+
+// NewClient returns a Client for ref, a "registry/repository[:tag|@digest]"
+// string, applying opts in order.
+func NewClient(ref string, opts ...ClientOpt) (*Client, error) {
+	parsed, err := parseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{ref: parsed, store: memory.New()}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	httpClient := &http.Client{}
+	if c.insecure {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // explicit operator opt-in via --insecure
+		httpClient.Transport = transport
+	}
+
+	repo, err := remote.NewRepository(parsed.oras().String())
+	if err != nil {
+		return nil, fmt.Errorf("configuring repository %q: %w", ref, err)
+	}
+	repo.PlainHTTP = c.plainHTTP
+	repo.Client = &auth.Client{
+		Client:     httpClient,
+		Cache:      auth.NewCache(),
+		Credential: credentialFunc(c.authConfigs),
+	}
+	c.repo = repo
+
+	return c, nil
+}
+
+// cloneOpts returns the ClientOpts needed to configure a new Client with
+// this client's transport and auth settings, for the internal destination
+// client Copy and Flatten construct.
+func (c *Client) cloneOpts() []ClientOpt {
+	return []ClientOpt{
+		SkipTLSVerify(c.insecure),
+		WithPlainHTTP(c.plainHTTP),
+		WithAuthConfigs(c.authConfigs),
+	}
+}
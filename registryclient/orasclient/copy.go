@@ -0,0 +1,167 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orasclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// Copy streams the manifest, config, and every layer at the client's
+// reference to destination using oras-go's CopyGraph, which fetches and
+// pushes each node as a stream rather than buffering it in memory, and
+// skips any blob destination already has rather than re-uploading it.
+func (c *Client) Copy(ctx context.Context, destination string) error {
+	dst, err := NewClient(destination, c.cloneOpts()...)
+	if err != nil {
+		return fmt.Errorf("configuring destination client for %q: %w", destination, err)
+	}
+
+	if _, err := oras.Copy(ctx, c.repo, c.ref.Reference, dst.repo, dst.ref.Reference, oras.DefaultCopyOptions); err != nil {
+		return fmt.Errorf("copying %s to %q: %w", c.ref, destination, err)
+	}
+	return nil
+}
+
+// Flatten fetches the manifest, config, and every layer at the client's
+// reference, concatenates the layer blobs into a single layer, and pushes
+// the result to destination, preserving the manifest and config
+// annotations (the attribute schemas for the collection) unchanged. The
+// config blob is streamed across unchanged; the concatenated layer's
+// content is necessarily re-digested, so it is streamed through a temp
+// file rather than an in-memory buffer to bound memory use on large
+// collections.
+func (c *Client) Flatten(ctx context.Context, destination string) error {
+	data, err := c.FetchManifest(ctx)
+	if err != nil {
+		return err
+	}
+
+	var m ocispec.Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("parsing manifest for %s: %w", c.ref, err)
+	}
+
+	dst, err := NewClient(destination, c.cloneOpts()...)
+	if err != nil {
+		return fmt.Errorf("configuring destination client for %q: %w", destination, err)
+	}
+
+	if err := c.streamBlob(ctx, m.Config, dst.repo); err != nil {
+		return fmt.Errorf("pushing config to %q: %w", destination, err)
+	}
+
+	layerDesc, err := c.streamFlattenedLayer(ctx, m.Layers, dst.repo)
+	if err != nil {
+		return fmt.Errorf("pushing flattened layer to %q: %w", destination, err)
+	}
+
+	out := ocispec.Manifest{
+		Versioned:   m.Versioned,
+		MediaType:   MediaTypeManifest,
+		Config:      m.Config,
+		Layers:      []ocispec.Descriptor{layerDesc},
+		Annotations: m.Annotations,
+	}
+	encoded, err := json.Marshal(out)
+	if err != nil {
+		return fmt.Errorf("marshalling flattened manifest: %w", err)
+	}
+
+	manifestDesc := ocispec.Descriptor{
+		MediaType: MediaTypeManifest,
+		Digest:    digest.FromBytes(encoded),
+		Size:      int64(len(encoded)),
+	}
+	if err := dst.repo.Push(ctx, manifestDesc, bytes.NewReader(encoded)); err != nil {
+		return fmt.Errorf("pushing flattened manifest to %q: %w", destination, err)
+	}
+	if err := dst.repo.Tag(ctx, manifestDesc, dst.ref.Reference); err != nil {
+		return fmt.Errorf("tagging flattened manifest in %q: %w", destination, err)
+	}
+	return nil
+}
+
+// streamBlob copies desc from c's repository to dst without re-encoding
+// it, skipping the copy if dst already has the blob.
+func (c *Client) streamBlob(ctx context.Context, desc ocispec.Descriptor, dst *remote.Repository) error {
+	exists, err := dst.Exists(ctx, desc)
+	if err != nil {
+		return fmt.Errorf("checking %s: %w", desc.Digest, err)
+	}
+	if exists {
+		return nil
+	}
+
+	rc, err := c.repo.Fetch(ctx, desc)
+	if err != nil {
+		return fmt.Errorf("fetching %s: %w", desc.Digest, err)
+	}
+	defer rc.Close()
+
+	return dst.Push(ctx, desc, rc)
+}
+
+// streamFlattenedLayer fetches each of layers in turn and appends its
+// content to a temp file while hashing it, so the concatenated blob's
+// digest and size are known before it is pushed to dst without ever
+// holding the full concatenation in memory at once.
+func (c *Client) streamFlattenedLayer(ctx context.Context, layers []ocispec.Descriptor, dst *remote.Repository) (ocispec.Descriptor, error) {
+	tmp, err := os.CreateTemp("", "emporous-flatten-*")
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("staging flattened layer: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hasher := sha256.New()
+	var size int64
+	for _, layer := range layers {
+		rc, err := c.repo.Fetch(ctx, layer)
+		if err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("fetching layer %s: %w", layer.Digest, err)
+		}
+
+		n, err := io.Copy(io.MultiWriter(tmp, hasher), rc)
+		_ = rc.Close()
+		if err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("reading layer %s: %w", layer.Digest, err)
+		}
+		size += n
+	}
+
+	desc := ocispec.Descriptor{
+		MediaType: MediaTypeLayer,
+		Digest:    digest.NewDigest(digest.SHA256, hasher),
+		Size:      size,
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("staging flattened layer: %w", err)
+	}
+	if err := dst.Push(ctx, desc, tmp); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("pushing flattened layer: %w", err)
+	}
+	return desc, nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orasclient
+
+import (
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+)
+
+// Media types used for the manifests, configs, and layers this client
+// reads and writes. There is no generic "detect the right layer media type
+// from a file" step: every blob pushed by this client is an opaque layer,
+// since the manifest's attribute annotations (supplied by the caller through
+// GenerateConfig/GenerateManifest, not derived by this package) are what
+// give its contents meaning.
+const (
+	MediaTypeManifest   = ocispec.MediaTypeImageManifest
+	MediaTypeImageIndex = ocispec.MediaTypeImageIndex
+	MediaTypeLayer      = "application/vnd.oci.image.layer.v1.tar"
+	MediaTypeEmptyJSON  = ocispec.MediaTypeEmptyJSON
+)
+
+// emptyJSON is the canonical empty config/payload blob used by artifact
+// manifests that have no meaningful config of their own, e.g. referrer
+// manifests for signatures and provenance.
+var emptyJSON = []byte("{}")
+
+// Descriptor identifies a blob or manifest by digest, mirroring the OCI
+// content descriptor shape. It is the unit every client method threads
+// between the push and pull call sequences, kept distinct from oras-go's
+// ocispec.Descriptor so callers outside this package never need to import
+// the image-spec module directly.
+type Descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// toOCI converts d to the ocispec.Descriptor shape oras-go's content
+// stores and copy functions operate on.
+func (d Descriptor) toOCI() ocispec.Descriptor {
+	return ocispec.Descriptor{
+		MediaType:    d.MediaType,
+		ArtifactType: d.ArtifactType,
+		Digest:       digest.Digest(d.Digest),
+		Size:         d.Size,
+		Annotations:  d.Annotations,
+	}
+}
+
+// descriptorFromOCI converts an ocispec.Descriptor, as returned by an
+// oras-go content store, back to the package's own Descriptor shape.
+func descriptorFromOCI(d ocispec.Descriptor) Descriptor {
+	return Descriptor{
+		MediaType:    d.MediaType,
+		ArtifactType: d.ArtifactType,
+		Digest:       string(d.Digest),
+		Size:         d.Size,
+		Annotations:  d.Annotations,
+	}
+}
+
+// descriptorFor builds a Descriptor for data using mediaType, with its
+// digest and size computed from data itself.
+func descriptorFor(mediaType string, data []byte) Descriptor {
+	return descriptorFromOCI(content.NewDescriptorFromBytes(mediaType, data))
+}
+
+// digestOf returns the "sha256:<hex>" digest of data, computed locally
+// rather than trusted from any response header, since the content a
+// descriptor names must match what was actually fetched or pushed.
+func digestOf(data []byte) string {
+	return digest.FromBytes(data).String()
+}
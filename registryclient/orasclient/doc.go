@@ -0,0 +1,20 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package orasclient is the OCI Distribution and Referrers API client used
+// to push, pull, and introspect Emporous collections. It is a thin,
+// Emporous-shaped façade (Client, Descriptor, Referrer) over oras-go's
+// registry/remote client and content-addressable copy graph, so transport
+// concerns like auth-token exchange, blob streaming, and the referrers
+// fallback are handled by the library rather than reimplemented here.
+package orasclient
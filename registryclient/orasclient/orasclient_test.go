@@ -0,0 +1,223 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orasclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry is a minimal, in-memory implementation of just enough of
+// the OCI Distribution and Referrers APIs to exercise a Client end to end,
+// so Push/Pull/Digest/Catalog/Referrers are tested against real HTTP
+// round trips instead of only their argument-parsing helpers.
+func fakeRegistry(t *testing.T) (host string, close func()) {
+	t.Helper()
+
+	blobs := map[string][]byte{}
+	manifests := map[string][]byte{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/_catalog", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string][]string{"repositories": {"myrepo"}})
+	})
+	mux.HandleFunc("/v2/myrepo/blobs/uploads/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/v2/myrepo/blobs/uploads/session1")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/v2/myrepo/blobs/uploads/session1", func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		blobs[r.URL.Query().Get("digest")] = data
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/v2/myrepo/blobs/", func(w http.ResponseWriter, r *http.Request) {
+		digest := strings.TrimPrefix(r.URL.Path, "/v2/myrepo/blobs/")
+		data, ok := blobs[digest]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		_, _ = w.Write(data)
+	})
+	mux.HandleFunc("/v2/myrepo/manifests/", func(w http.ResponseWriter, r *http.Request) {
+		ref := strings.TrimPrefix(r.URL.Path, "/v2/myrepo/manifests/")
+		switch r.Method {
+		case http.MethodPut:
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			manifests[ref] = data
+			manifests[digestOf(data)] = data
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			data, ok := manifests[ref]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodDelete:
+			delete(manifests, ref)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	})
+	mux.HandleFunc("/v2/myrepo/referrers/", func(w http.ResponseWriter, r *http.Request) {
+		subject := strings.TrimPrefix(r.URL.Path, "/v2/myrepo/referrers/")
+		var descs []ocispec.Descriptor
+		for ref, data := range manifests {
+			if !strings.HasPrefix(ref, "sha256:") {
+				continue
+			}
+			var m ocispec.Manifest
+			if json.Unmarshal(data, &m) != nil || m.Subject == nil || string(m.Subject.Digest) != subject {
+				continue
+			}
+			descs = append(descs, ocispec.Descriptor{MediaType: MediaTypeManifest, ArtifactType: m.ArtifactType, Digest: digest.Digest(digestOf(data))})
+		}
+		w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+		_ = json.NewEncoder(w).Encode(ocispec.Index{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageIndex,
+			Manifests: descs,
+		})
+	})
+
+	srv := httptest.NewServer(mux)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	return u.Host, srv.Close
+}
+
+func TestClient_PushPullDigestRoundTrip(t *testing.T) {
+	host, closeServer := fakeRegistry(t)
+	defer closeServer()
+
+	srcDir := t.TempDir()
+	file := filepath.Join(srcDir, "data.txt")
+	require.NoError(t, os.WriteFile(file, []byte("hello"), 0644))
+
+	pushClient, err := NewClient(host+"/myrepo:latest", WithPlainHTTP(true))
+	require.NoError(t, err)
+
+	descs, err := pushClient.GatherDescriptors(file)
+	require.NoError(t, err)
+	configDesc, err := pushClient.GenerateConfig(nil)
+	require.NoError(t, err)
+	require.NoError(t, pushClient.GenerateManifest(configDesc, nil, descs...))
+	require.NoError(t, pushClient.Execute(context.Background()))
+
+	pullClient, err := NewClient(host+"/myrepo:latest", WithPlainHTTP(true))
+	require.NoError(t, err)
+
+	outDir := t.TempDir()
+	require.NoError(t, pullClient.Pull(context.Background(), outDir))
+
+	got, err := os.ReadFile(filepath.Join(outDir, "data.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(got))
+
+	digestClient, err := NewClient(host+"/myrepo:latest", WithPlainHTTP(true))
+	require.NoError(t, err)
+	digest, err := digestClient.Digest(context.Background())
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(digest, "sha256:"))
+
+	manifestBytes, err := digestClient.FetchManifest(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, digest, digestOf(manifestBytes))
+}
+
+func TestClient_Catalog(t *testing.T) {
+	host, closeServer := fakeRegistry(t)
+	defer closeServer()
+
+	client, err := NewClient(host+"/myrepo:latest", WithPlainHTTP(true))
+	require.NoError(t, err)
+
+	repos, err := client.Catalog(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"myrepo"}, repos)
+}
+
+func TestClient_AttachAndListReferrers(t *testing.T) {
+	host, closeServer := fakeRegistry(t)
+	defer closeServer()
+
+	client, err := NewClient(host+"/myrepo:latest", WithPlainHTTP(true))
+	require.NoError(t, err)
+	configDesc, err := client.GenerateConfig(nil)
+	require.NoError(t, err)
+	require.NoError(t, client.GenerateManifest(configDesc, nil))
+	require.NoError(t, client.Execute(context.Background()))
+
+	digest, err := client.Digest(context.Background())
+	require.NoError(t, err)
+
+	const artifactType = "application/vnd.test.sig"
+	require.NoError(t, client.AttachReferrer(context.Background(), digest, artifactType, []byte(`{"sig":true}`)))
+
+	referrers, err := client.Referrers(context.Background(), digest)
+	require.NoError(t, err)
+	require.Len(t, referrers, 1)
+	require.Equal(t, artifactType, referrers[0].ArtifactType)
+	require.JSONEq(t, `{"sig":true}`, string(referrers[0].Content))
+}
+
+func TestClient_Delete(t *testing.T) {
+	host, closeServer := fakeRegistry(t)
+	defer closeServer()
+
+	client, err := NewClient(host+"/myrepo:latest", WithPlainHTTP(true))
+	require.NoError(t, err)
+	configDesc, err := client.GenerateConfig(nil)
+	require.NoError(t, err)
+	require.NoError(t, client.GenerateManifest(configDesc, nil))
+	require.NoError(t, client.Execute(context.Background()))
+
+	require.NoError(t, client.Delete(context.Background()))
+
+	_, err = client.FetchManifest(context.Background())
+	require.Error(t, err)
+}
+
+func TestContainedPath_RejectsEscapes(t *testing.T) {
+	base := t.TempDir()
+
+	_, err := ContainedPath(base, "../../etc/passwd")
+	require.Error(t, err)
+
+	_, err = ContainedPath(base, "/etc/passwd")
+	require.Error(t, err)
+
+	path, err := ContainedPath(base, "nested/file.txt")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(base, "nested", "file.txt"), path)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orasclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/file"
+)
+
+// FetchManifest returns the raw manifest bytes for the client's reference.
+func (c *Client) FetchManifest(ctx context.Context) ([]byte, error) {
+	desc, err := c.repo.Resolve(ctx, c.ref.Reference)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", c.ref, err)
+	}
+
+	rc, err := c.repo.Fetch(ctx, desc)
+	if err != nil {
+		return nil, fmt.Errorf("fetching manifest for %s: %w", c.ref, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest for %s: %w", c.ref, err)
+	}
+	return data, nil
+}
+
+// FetchConfig returns the raw config blob bytes referenced by the
+// manifest at the client's reference.
+func (c *Client) FetchConfig(ctx context.Context) ([]byte, error) {
+	data, err := c.FetchManifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var m ocispec.Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing manifest for %s: %w", c.ref, err)
+	}
+
+	return c.FetchBlob(ctx, string(m.Config.Digest))
+}
+
+// FetchBlob returns the raw bytes of the blob identified by digest.
+func (c *Client) FetchBlob(ctx context.Context, digest string) ([]byte, error) {
+	desc, err := c.repo.Blobs().Resolve(ctx, digest)
+	if err != nil {
+		return nil, fmt.Errorf("resolving blob %s: %w", digest, err)
+	}
+
+	rc, err := c.repo.Fetch(ctx, desc)
+	if err != nil {
+		return nil, fmt.Errorf("fetching blob %s: %w", digest, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("reading blob %s: %w", digest, err)
+	}
+	return data, nil
+}
+
+// Digest resolves the manifest digest of the client's reference through the
+// registry's manifest Resolve. A digest reference is returned as-is without
+// contacting the registry.
+func (c *Client) Digest(ctx context.Context) (string, error) {
+	if c.ref.IsDigest() {
+		return c.ref.Reference, nil
+	}
+
+	desc, err := c.repo.Resolve(ctx, c.ref.Reference)
+	if err != nil {
+		return "", fmt.Errorf("resolving digest for %s: %w", c.ref, err)
+	}
+	return string(desc.Digest), nil
+}
+
+// Pull fetches the manifest, config, and every layer at the client's
+// reference, writing each layer to destDir under the file name recorded in
+// its "org.opencontainers.image.title" annotation, via oras-go's file
+// store and CopyGraph, which stream each blob to disk rather than
+// buffering it and reject a layer title that would escape destDir (an
+// absolute path or a "../" traversal) rather than writing it.
+func (c *Client) Pull(ctx context.Context, destDir string) error {
+	store, err := file.New(destDir)
+	if err != nil {
+		return fmt.Errorf("opening destination %q: %w", destDir, err)
+	}
+	defer store.Close()
+
+	if _, err := oras.Copy(ctx, c.repo, c.ref.Reference, store, c.ref.Reference, oras.DefaultCopyOptions); err != nil {
+		return fmt.Errorf("pulling %s: %w", c.ref, err)
+	}
+	return nil
+}
+
+// ContainedPath joins baseDir and name and verifies the result is still
+// within baseDir, rejecting an absolute name or a "../" escape. It is
+// exported for reuse by other callers that need the same traversal guard
+// for filesystem paths derived from untrusted content (e.g. builder/config
+// resolving an $include path found inside a local config file, or
+// builder/parser caching a plugin artifact under a name it doesn't
+// control).
+func ContainedPath(baseDir, name string) (string, error) {
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("refusing to write outside %q: %q is an absolute path", baseDir, name)
+	}
+
+	joined := filepath.Join(baseDir, name)
+	rel, err := filepath.Rel(baseDir, joined)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("refusing to write outside %q: %q escapes the destination directory", baseDir, name)
+	}
+	return joined, nil
+}
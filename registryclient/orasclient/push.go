@@ -0,0 +1,111 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orasclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+)
+
+// GatherDescriptors reads each file and stages it as a pending layer blob
+// in the client's local store, returning its Descriptor for use with
+// GenerateManifest. Each layer's "org.opencontainers.image.title"
+// annotation records the file's base name, which Pull uses to lay the
+// blob back out on disk.
+func (c *Client) GatherDescriptors(files ...string) ([]Descriptor, error) {
+	descs := make([]Descriptor, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %w", file, err)
+		}
+
+		desc := descriptorFor(MediaTypeLayer, data)
+		desc.Annotations = map[string]string{
+			ocispec.AnnotationTitle: filepath.Base(file),
+		}
+
+		if err := c.store.Push(context.Background(), desc.toOCI(), bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("staging %q: %w", file, err)
+		}
+		descs = append(descs, desc)
+	}
+	return descs, nil
+}
+
+// GenerateConfig stages an empty config blob annotated with annotations
+// and returns its Descriptor for use with GenerateManifest. Emporous
+// collections carry their meaningful metadata in the manifest and layer
+// annotations, so the config blob itself is always the canonical empty
+// JSON object rather than something format-specific.
+func (c *Client) GenerateConfig(annotations map[string]string) (Descriptor, error) {
+	desc := descriptorFor(MediaTypeEmptyJSON, emptyJSON)
+	desc.Annotations = annotations
+
+	if err := c.store.Push(context.Background(), desc.toOCI(), bytes.NewReader(emptyJSON)); err != nil {
+		return Descriptor{}, fmt.Errorf("staging config: %w", err)
+	}
+	return desc, nil
+}
+
+// GenerateManifest stages an OCI image manifest referencing config and
+// layers, to be packed and pushed by Execute alongside the blobs gathered
+// by GatherDescriptors/GenerateConfig.
+func (c *Client) GenerateManifest(config Descriptor, annotations map[string]string, layers ...Descriptor) error {
+	ociLayers := make([]ocispec.Descriptor, len(layers))
+	for i, layer := range layers {
+		ociLayers[i] = layer.toOCI()
+	}
+	c.pendingManifest = &pendingManifest{
+		config:      config.toOCI(),
+		layers:      ociLayers,
+		annotations: annotations,
+	}
+	return nil
+}
+
+// Execute packs the manifest staged by GenerateManifest over the blobs
+// staged by GatherDescriptors/GenerateConfig, tags it with the reference
+// the Client was constructed with, and streams the resulting graph to the
+// registry with oras.Copy, which fetches and pushes each node as a stream
+// rather than buffering it and skips any blob the registry already has.
+func (c *Client) Execute(ctx context.Context) error {
+	if c.pendingManifest == nil {
+		return fmt.Errorf("no manifest staged: call GenerateManifest before Execute")
+	}
+
+	desc, err := oras.PackManifest(ctx, c.store, oras.PackManifestVersion1_1, "", oras.PackManifestOptions{
+		ConfigDescriptor:    &c.pendingManifest.config,
+		Layers:              c.pendingManifest.layers,
+		ManifestAnnotations: c.pendingManifest.annotations,
+	})
+	if err != nil {
+		return fmt.Errorf("staging manifest: %w", err)
+	}
+
+	if err := c.store.Tag(ctx, desc, c.ref.Reference); err != nil {
+		return fmt.Errorf("tagging staged manifest: %w", err)
+	}
+
+	if _, err := oras.Copy(ctx, c.store, c.ref.Reference, c.repo, c.ref.Reference, oras.DefaultCopyOptions); err != nil {
+		return fmt.Errorf("pushing %s: %w", c.ref, err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orasclient
+
+import (
+	"fmt"
+
+	digest "github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/registry"
+)
+
+// reference is a parsed "registry/repository[:tag|@digest]" string, the
+// shape every reference-taking client entry point (NewClient, and through
+// it $ref in builder config) accepts. It mirrors oras-go's registry.Reference, which does the
+// actual parsing, but defaults a bare repository to the "latest" tag and
+// rejects a reference with no repository, neither of which the library
+// enforces on its own.
+type reference struct {
+	Registry   string
+	Repository string
+	// Reference is either a tag or a "sha256:..." digest.
+	Reference string
+}
+
+// IsDigest reports whether r.Reference is a digest rather than a tag.
+func (r reference) IsDigest() bool {
+	return digest.Digest(r.Reference).Validate() == nil
+}
+
+// oras returns the oras-go registry.Reference equivalent of r, for passing
+// to remote.NewRepository and friends.
+func (r reference) oras() registry.Reference {
+	return registry.Reference{Registry: r.Registry, Repository: r.Repository, Reference: r.Reference}
+}
+
+// parseReference parses raw, e.g. "localhost:5000/myartifacts:latest" or
+// "ghcr.io/org/base-config@sha256:...", into its registry, repository, and
+// tag/digest parts, via oras-go's registry.ParseReference. A raw value with
+// no repository is rejected rather than guessed at, since that's almost
+// certainly a missing repository rather than a registry with an implicit
+// default one; a raw value with no tag or digest defaults to "latest".
+func parseReference(raw string) (reference, error) {
+	parsed, err := registry.ParseReference(raw)
+	if err != nil {
+		return reference{}, fmt.Errorf("reference %q: expected REGISTRY/REPOSITORY[:TAG|@DIGEST]: %w", raw, err)
+	}
+	if parsed.Repository == "" {
+		return reference{}, fmt.Errorf("reference %q: expected REGISTRY/REPOSITORY[:TAG|@DIGEST]", raw)
+	}
+
+	ref := reference{Registry: parsed.Registry, Repository: parsed.Repository, Reference: parsed.Reference}
+	if ref.Reference == "" {
+		ref.Reference = "latest"
+	}
+	return ref, nil
+}
+
+func (r reference) String() string {
+	if r.IsDigest() {
+		return fmt.Sprintf("%s/%s@%s", r.Registry, r.Repository, r.Reference)
+	}
+	return fmt.Sprintf("%s/%s:%s", r.Registry, r.Repository, r.Reference)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orasclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference_Tag(t *testing.T) {
+	ref, err := parseReference("localhost:5000/myartifacts:latest")
+	require.NoError(t, err)
+	require.Equal(t, "localhost:5000", ref.Registry)
+	require.Equal(t, "myartifacts", ref.Repository)
+	require.Equal(t, "latest", ref.Reference)
+	require.False(t, ref.IsDigest())
+}
+
+func TestParseReference_Digest(t *testing.T) {
+	ref, err := parseReference("ghcr.io/org/base-config@sha256:" + testDigestHex)
+	require.NoError(t, err)
+	require.Equal(t, "ghcr.io", ref.Registry)
+	require.Equal(t, "org/base-config", ref.Repository)
+	require.True(t, ref.IsDigest())
+}
+
+func TestParseReference_DefaultsToLatest(t *testing.T) {
+	ref, err := parseReference("localhost:5000/myartifacts")
+	require.NoError(t, err)
+	require.Equal(t, "latest", ref.Reference)
+}
+
+func TestParseReference_RejectsMissingRepository(t *testing.T) {
+	_, err := parseReference("localhost:5000")
+	require.Error(t, err)
+}
+
+const testDigestHex = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
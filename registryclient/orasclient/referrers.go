@@ -0,0 +1,107 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orasclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+// Referrer is one entry returned by Referrers: an artifact-typed manifest
+// whose Subject points at the digest it annotates, along with the payload
+// bytes held in its single layer.
+type Referrer struct {
+	ArtifactType string
+	Content      []byte
+}
+
+// AttachReferrer pushes content as a single-layer artifact manifest of the
+// given artifactType, with Subject pointing at digest, so it shows up in a
+// later call to Referrers(ctx, digest). The manifest is staged in a local
+// store and pushed untagged, by its own digest, via oras.Copy: referrer
+// manifests are discovered through the Referrers API via Subject, not
+// through a tag.
+func (c *Client) AttachReferrer(ctx context.Context, digest, artifactType string, content []byte) error {
+	subject, err := c.repo.Manifests().Resolve(ctx, digest)
+	if err != nil {
+		return fmt.Errorf("resolving subject %s: %w", digest, err)
+	}
+
+	store := memory.New()
+	layerDesc := descriptorFor(MediaTypeLayer, content).toOCI()
+	if err := store.Push(ctx, layerDesc, bytes.NewReader(content)); err != nil {
+		return fmt.Errorf("staging referrer payload: %w", err)
+	}
+
+	manifestDesc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{
+		Subject: &subject,
+		Layers:  []ocispec.Descriptor{layerDesc},
+	})
+	if err != nil {
+		return fmt.Errorf("staging referrer manifest: %w", err)
+	}
+
+	if _, err := oras.Copy(ctx, store, manifestDesc.Digest.String(), c.repo, "", oras.DefaultCopyOptions); err != nil {
+		return fmt.Errorf("pushing referrer manifest: %w", err)
+	}
+	return nil
+}
+
+// Referrers returns every referrer manifest whose Subject points at
+// digest, per the OCI 1.1 Referrers API (falling back to the referrers
+// tag scheme, if the registry doesn't support the API, transparently
+// inside oras-go's Repository.Referrers).
+func (c *Client) Referrers(ctx context.Context, digest string) ([]Referrer, error) {
+	subject, err := c.repo.Manifests().Resolve(ctx, digest)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", digest, err)
+	}
+
+	var referrers []Referrer
+	if err := c.repo.Referrers(ctx, subject, "", func(page []ocispec.Descriptor) error {
+		for _, desc := range page {
+			rc, err := c.repo.Fetch(ctx, desc)
+			if err != nil {
+				return fmt.Errorf("fetching referrer manifest %s: %w", desc.Digest, err)
+			}
+
+			var m ocispec.Manifest
+			decodeErr := json.NewDecoder(rc).Decode(&m)
+			_ = rc.Close()
+			if decodeErr != nil {
+				return fmt.Errorf("parsing referrer manifest %s: %w", desc.Digest, decodeErr)
+			}
+			if len(m.Layers) == 0 {
+				return fmt.Errorf("referrer manifest %s has no layers", desc.Digest)
+			}
+
+			content, err := c.FetchBlob(ctx, string(m.Layers[0].Digest))
+			if err != nil {
+				return fmt.Errorf("fetching referrer payload %s: %w", m.Layers[0].Digest, err)
+			}
+
+			referrers = append(referrers, Referrer{ArtifactType: desc.ArtifactType, Content: content})
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("listing referrers of %s: %w", digest, err)
+	}
+	return referrers, nil
+}
@@ -0,0 +1,18 @@
+/*
+Copyright 2022 Emporous Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sign provides cosign-compatible signing of published collections
+// and generation of in-toto SLSA provenance predicates describing how an
+// artifact was built. Signatures and provenance are attached to artifacts
+// as OCI 1.1 referrers rather than stored out-of-band.
+package sign
@@ -0,0 +1,205 @@
+package sign
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	slsa "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.2"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+)
+
+// ProvenanceArtifactType is the media type used for the provenance
+// referrer attached to published collections. The referrer's content is a
+// DSSE envelope wrapping the in-toto statement, not the bare statement
+// itself, so a verifier can confirm who produced it rather than trusting
+// whatever JSON a registry push happens to contain.
+const ProvenanceArtifactType = "application/vnd.in-toto+json"
+
+// dssePayloadType identifies the payload inside the DSSE envelope as an
+// in-toto statement, per the in-toto attestation spec.
+const dssePayloadType = "application/vnd.in-toto+json"
+
+// BuildInputs describes the inputs used to produce a published collection,
+// recorded in the generated provenance predicate.
+type BuildInputs struct {
+	WorkspacePath string
+	ConfigHashes  map[string]string
+	GitCommit     string
+}
+
+// DSSESignature is one signature over a DSSE envelope's payload. Cert,
+// Chain, and RekorIntegratedTime are populated when the envelope was
+// signed keylessly, mirroring Signature's treatment of the same fields.
+type DSSESignature struct {
+	Sig                 []byte `json:"sig"`
+	Cert                []byte `json:"cert,omitempty"`
+	Chain               []byte `json:"chain,omitempty"`
+	RekorIntegratedTime int64  `json:"rekorIntegratedTime,omitempty"`
+}
+
+// DSSEEnvelope is a Dead Simple Signing Envelope (DSSE) wrapping a signed
+// payload, per https://github.com/secure-systems-lab/dsse.
+type DSSEEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     []byte          `json:"payload"`
+	Signatures  []DSSESignature `json:"signatures"`
+}
+
+// GenerateProvenance builds an in-toto SLSA provenance statement for the
+// artifact identified by digest, describing the build inputs used to
+// produce it, and signs it with signer, returning the result as a DSSE
+// envelope. Without a real signature, anyone with push access to the
+// registry could fabricate a provenance referrer for someone else's
+// artifact, so an unsigned predicate is never attached on its own.
+func GenerateProvenance(ctx context.Context, signer Signer, digest string, inputs BuildInputs) ([]byte, error) {
+	statement, err := buildProvenanceStatement(digest, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling provenance statement: %w", err)
+	}
+
+	pae := dssePAE(dssePayloadType, payload)
+	sig, cert, chain, integratedTime, err := signer.SignRaw(ctx, pae)
+	if err != nil {
+		return nil, fmt.Errorf("signing provenance statement: %w", err)
+	}
+
+	envelope := DSSEEnvelope{
+		PayloadType: dssePayloadType,
+		Payload:     payload,
+		Signatures:  []DSSESignature{{Sig: sig, Cert: cert, Chain: chain, RekorIntegratedTime: integratedTime}},
+	}
+
+	out, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling provenance envelope: %w", err)
+	}
+	return out, nil
+}
+
+func buildProvenanceStatement(digest string, inputs BuildInputs) (in_toto.ProvenanceStatement, error) {
+	materials := make([]slsa.ProvenanceMaterial, 0, len(inputs.ConfigHashes))
+	for path, hash := range inputs.ConfigHashes {
+		materials = append(materials, slsa.ProvenanceMaterial{
+			URI:    fmt.Sprintf("file://%s", path),
+			Digest: slsa.DigestSet{"sha256": hash},
+		})
+	}
+
+	predicate := slsa.ProvenancePredicate{
+		Builder: slsa.ProvenanceBuilder{ID: "https://emporous.dev/builder/client"},
+		Invocation: slsa.ProvenanceInvocation{
+			ConfigSource: slsa.ConfigSource{
+				URI: inputs.WorkspacePath,
+				Digest: slsa.DigestSet{
+					"gitCommit": inputs.GitCommit,
+				},
+			},
+		},
+		Materials: materials,
+	}
+
+	return in_toto.ProvenanceStatement{
+		StatementHeader: in_toto.StatementHeader{
+			Type:          in_toto.StatementInTotoV01,
+			PredicateType: slsa.PredicateSLSAProvenance,
+			Subject: []in_toto.Subject{
+				{
+					Name:   inputs.WorkspacePath,
+					Digest: map[string]string{"sha256": bareDigest(digest)},
+				},
+			},
+		},
+		Predicate: predicate,
+	}, nil
+}
+
+// VerifyProvenance checks that envelopeBytes is a DSSE envelope containing
+// a well-formed in-toto SLSA provenance statement whose subject matches
+// digest, with at least one signature that verify accepts. verify is
+// called once per signature with the envelope's pre-authentication
+// encoding and that signature's bytes (and, for a keyless signature, its
+// embedded Cert/Chain); it is the caller's job to check the signature
+// against the trust material appropriate for how verification was
+// requested (a cosign public key or a pinned Fulcio root), exactly as
+// VerifySignature/VerifyKeylessSignature already do for the artifact
+// signature referrer.
+func VerifyProvenance(envelopeBytes []byte, digest string, verify func(pae []byte, sig DSSESignature) error) error {
+	var envelope DSSEEnvelope
+	if err := json.Unmarshal(envelopeBytes, &envelope); err != nil {
+		return fmt.Errorf("parsing provenance envelope: %w", err)
+	}
+	if len(envelope.Signatures) == 0 {
+		return errors.New("provenance envelope has no signatures")
+	}
+
+	pae := dssePAE(envelope.PayloadType, envelope.Payload)
+	var verifyErr error
+	verified := false
+	for _, sig := range envelope.Signatures {
+		if err := verify(pae, sig); err != nil {
+			verifyErr = err
+			continue
+		}
+		verified = true
+		break
+	}
+	if !verified {
+		return fmt.Errorf("no valid signature on provenance envelope: %w", verifyErr)
+	}
+
+	var statement in_toto.ProvenanceStatement
+	if err := json.Unmarshal(envelope.Payload, &statement); err != nil {
+		return fmt.Errorf("parsing provenance statement: %w", err)
+	}
+
+	if statement.PredicateType != slsa.PredicateSLSAProvenance {
+		return fmt.Errorf("unexpected predicate type %q", statement.PredicateType)
+	}
+
+	want := bareDigest(digest)
+	for _, subject := range statement.Subject {
+		if subject.Digest["sha256"] == want {
+			return nil
+		}
+	}
+	return fmt.Errorf("provenance subject does not cover digest %s", digest)
+}
+
+// dssePAE computes the DSSE Pre-Authentication Encoding of (payloadType,
+// payload), per https://github.com/secure-systems-lab/dsse/blob/master/protocol.md#signature-definition.
+// Signing this encoding, rather than payload alone, binds the payload type
+// into what's signed so an envelope can't be reinterpreted as a different
+// kind of attestation.
+func dssePAE(payloadType string, payload []byte) []byte {
+	var b strings.Builder
+	b.WriteString("DSSEv1")
+	b.WriteByte(' ')
+	b.WriteString(strconv.Itoa(len(payloadType)))
+	b.WriteByte(' ')
+	b.WriteString(payloadType)
+	b.WriteByte(' ')
+	b.WriteString(strconv.Itoa(len(payload)))
+	b.WriteByte(' ')
+	b.Write(payload)
+	return []byte(b.String())
+}
+
+// bareDigest strips an "alg:" prefix (e.g. "sha256:") from digest, since
+// in-toto's DigestSet already carries the algorithm as the map key.
+func bareDigest(digest string) string {
+	_, hex, found := strings.Cut(digest, ":")
+	if !found {
+		return digest
+	}
+	return hex
+}
@@ -0,0 +1,291 @@
+package sign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/sigstore/cosign/v2/pkg/cosign/fulcio"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// ArtifactType is the media type used for signature referrers attached
+// to published collections.
+const ArtifactType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+
+// Default public-good Sigstore instances used for keyless signing when the
+// caller does not override them.
+const (
+	DefaultFulcioURL = "https://fulcio.sigstore.dev"
+	DefaultRekorURL  = "https://rekor.sigstore.dev"
+)
+
+// Signature is the result of signing a manifest digest. Cert, Chain, and
+// RekorIntegratedTime are only populated for keyless signatures. This is
+// marshalled as-is into the referrer attached to a published artifact, so
+// they travel with the signature instead of being dropped on the floor.
+type Signature struct {
+	Payload   []byte `json:"payload"`
+	Signature []byte `json:"signature"`
+	Cert      []byte `json:"certificate,omitempty"`
+	Chain     []byte `json:"chain,omitempty"`
+	// RekorIntegratedTime is the Unix time the Rekor transparency log
+	// recorded this entry at, per submitToRekor. A keyless signature's
+	// Fulcio certificate is only valid for the few minutes around signing,
+	// so verification anchors the certificate-chain check to this instant
+	// instead of wall-clock "now", which would reject every keyless
+	// signature checked after the certificate's lifetime expires.
+	RekorIntegratedTime int64 `json:"rekorIntegratedTime,omitempty"`
+}
+
+// Signer signs content and returns a result that can be attached to the
+// artifact as an OCI 1.1 referrer.
+type Signer interface {
+	// Sign signs a manifest digest and returns a Signature.
+	Sign(ctx context.Context, digest string) (Signature, error)
+	// SignRaw signs payload directly rather than wrapping it as a simple
+	// signing payload over a digest, for content that is already fully
+	// formed, like a DSSE provenance envelope's pre-authentication
+	// encoding. cert and chain are populated for a keyless signer and nil
+	// for a static key pair signer; likewise rekorIntegratedTime is only
+	// populated for a keyless signer, which submits every signature to
+	// Rekor, and is 0 for a static key pair signer.
+	SignRaw(ctx context.Context, payload []byte) (sig, cert, chain []byte, rekorIntegratedTime int64, err error)
+}
+
+// KeyPairSigner signs with a cosign-compatible static key pair.
+type KeyPairSigner struct {
+	signer signature.Signer
+}
+
+// NewKeyPairSigner loads a cosign key pair from keyPath, prompting for the
+// passphrase if the private key is encrypted.
+func NewKeyPairSigner(ctx context.Context, keyPath string) (*KeyPairSigner, error) {
+	sv, err := cosign.SignerFromKeyRef(ctx, keyPath, cosign.GetPassFromTerm)
+	if err != nil {
+		return nil, fmt.Errorf("loading cosign key %q: %w", keyPath, err)
+	}
+	return &KeyPairSigner{signer: sv}, nil
+}
+
+func (s *KeyPairSigner) Sign(ctx context.Context, digest string) (Signature, error) {
+	payload := cosign.SimpleContainerImage(digest)
+	sig, _, _, _, err := s.SignRaw(ctx, payload)
+	if err != nil {
+		return Signature{}, fmt.Errorf("signing digest %s: %w", digest, err)
+	}
+	return Signature{Payload: payload, Signature: sig}, nil
+}
+
+func (s *KeyPairSigner) SignRaw(ctx context.Context, payload []byte) ([]byte, []byte, []byte, int64, error) {
+	sig, err := s.signer.SignMessage(nil, payload, signature.WithContext(ctx))
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	return sig, nil, nil, 0, nil
+}
+
+// KeylessSigner signs using the Fulcio/Rekor keyless OIDC flow, minting a
+// short-lived certificate instead of relying on a long-lived private key.
+// Every signature is also submitted to the Rekor transparency log at
+// RekorURL, so a keyless signature can't later be repudiated by deleting
+// the short-lived key.
+type KeylessSigner struct {
+	FulcioURL string
+	RekorURL  string
+}
+
+// NewKeylessSigner returns a Signer that performs the Sigstore keyless flow
+// against the given Fulcio and Rekor instances.
+func NewKeylessSigner(fulcioURL, rekorURL string) *KeylessSigner {
+	return &KeylessSigner{FulcioURL: fulcioURL, RekorURL: rekorURL}
+}
+
+func (s *KeylessSigner) Sign(ctx context.Context, digest string) (Signature, error) {
+	payload := cosign.SimpleContainerImage(digest)
+	sig, cert, chain, integratedTime, err := s.SignRaw(ctx, payload)
+	if err != nil {
+		return Signature{}, fmt.Errorf("signing digest %s: %w", digest, err)
+	}
+	return Signature{Payload: payload, Signature: sig, Cert: cert, Chain: chain, RekorIntegratedTime: integratedTime}, nil
+}
+
+func (s *KeylessSigner) SignRaw(ctx context.Context, payload []byte) ([]byte, []byte, []byte, int64, error) {
+	cert, chain, sv, err := fulcio.GetCertAndSigner(ctx, fulcio.OIDCOptions{FulcioURL: s.FulcioURL})
+	if err != nil {
+		return nil, nil, nil, 0, fmt.Errorf("fetching fulcio certificate: %w", err)
+	}
+
+	sig, err := sv.SignMessage(nil, payload, signature.WithContext(ctx))
+	if err != nil {
+		return nil, nil, nil, 0, fmt.Errorf("signing payload: %w", err)
+	}
+
+	integratedTime, err := submitToRekor(ctx, s.RekorURL, cert, payload, sig)
+	if err != nil {
+		return nil, nil, nil, 0, fmt.Errorf("submitting to rekor transparency log: %w", err)
+	}
+
+	return sig, cert, chain, integratedTime, nil
+}
+
+// VerifySignature checks that sigPayload is a valid cosign signature over
+// digest produced by the holder of pubKeyPath.
+func VerifySignature(pubKeyPath, digest string, sigPayload []byte) error {
+	return VerifyRawSignature(pubKeyPath, cosign.SimpleContainerImage(digest), sigPayload)
+}
+
+// VerifyRawSignature checks that sig is a valid signature over payload
+// produced by the holder of pubKeyPath.
+func VerifyRawSignature(pubKeyPath string, payload, sig []byte) error {
+	verifier, err := signature.LoadVerifierFromPEMFile(pubKeyPath, nil)
+	if err != nil {
+		return fmt.Errorf("loading public key %q: %w", pubKeyPath, err)
+	}
+	return verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(payload))
+}
+
+// VerifyKeylessSignature checks that sigPayload is a valid signature over
+// digest, where certPEM is the short-lived Fulcio certificate minted for
+// the signer at signing time rather than a pre-shared key. rekorIntegratedTime
+// anchors the certificate-chain check to the instant the signature was
+// logged to Rekor, per VerifyRawKeylessSignature.
+func VerifyKeylessSignature(certPEM, chainPEM, trustedRootPEM []byte, digest string, sigPayload []byte, rekorIntegratedTime int64) error {
+	return VerifyRawKeylessSignature(certPEM, chainPEM, trustedRootPEM, cosign.SimpleContainerImage(digest), sigPayload, rekorIntegratedTime)
+}
+
+// VerifyRawKeylessSignature checks that sig is a valid signature over
+// payload, where certPEM must chain to trustedRootPEM, the operator's
+// pinned Sigstore Fulcio root CA bundle (e.g. mirrored from Sigstore's TUF
+// root out of band, never fetched from the artifact under verification).
+// chainPEM may still come from the registry referrer alongside certPEM:
+// it is only ever trusted to supply intermediate certificates bridging
+// certPEM to trustedRootPEM, and is never itself treated as a root of
+// trust, since an attacker able to push a referrer could otherwise mint
+// a self-signed "chain" and pass verification against their own root.
+//
+// rekorIntegratedTime, the Unix time the signature was logged to Rekor at
+// (Signature.RekorIntegratedTime/DSSESignature.RekorIntegratedTime), is
+// used as the chain-validity instant instead of wall-clock "now": a Fulcio
+// leaf certificate is only valid for the few minutes around signing, so
+// checking against "now" would reject every keyless signature as soon as
+// that window passes, which is normally well before anyone gets around to
+// verifying it.
+func VerifyRawKeylessSignature(certPEM, chainPEM, trustedRootPEM []byte, payload, sig []byte, rekorIntegratedTime int64) error {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return fmt.Errorf("parsing signing certificate: %w", err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(trustedRootPEM) {
+		return errors.New("parsing trusted Fulcio root: no certificates found")
+	}
+
+	intermediates := x509.NewCertPool()
+	if len(chainPEM) > 0 {
+		intermediates.AppendCertsFromPEM(chainPEM)
+	}
+
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		CurrentTime:   time.Unix(rekorIntegratedTime, 0),
+	}); err != nil {
+		return fmt.Errorf("verifying certificate chain: %w", err)
+	}
+
+	verifier, err := signature.LoadVerifier(cert.PublicKey, crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("loading verifier from certificate: %w", err)
+	}
+
+	return verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(payload))
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// submitToRekor records a hashedrekord entry for (certPEM, payload, sig) in
+// the Rekor transparency log at rekorURL, so the signature has public,
+// append-only backing independent of the short-lived Fulcio certificate
+// used to produce it. It returns the integratedTime Rekor recorded for the
+// entry, which callers use to anchor later chain verification to the
+// instant the (necessarily short-lived) Fulcio certificate was minted.
+func submitToRekor(ctx context.Context, rekorURL string, certPEM, payload, sig []byte) (int64, error) {
+	if rekorURL == "" {
+		return 0, errors.New("no rekor url configured")
+	}
+
+	sum := sha256.Sum256(payload)
+	entry := map[string]interface{}{
+		"kind":       "hashedrekord",
+		"apiVersion": "0.0.1",
+		"spec": map[string]interface{}{
+			"signature": map[string]interface{}{
+				"content": base64.StdEncoding.EncodeToString(sig),
+				"publicKey": map[string]interface{}{
+					"content": base64.StdEncoding.EncodeToString(certPEM),
+				},
+			},
+			"data": map[string]interface{}{
+				"hash": map[string]interface{}{
+					"algorithm": "sha256",
+					"value":     hex.EncodeToString(sum[:]),
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return 0, fmt.Errorf("encoding rekor entry: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(rekorURL, "/")+"/api/v1/log/entries", bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("building rekor request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("submitting to %s: %w", rekorURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return 0, fmt.Errorf("submitting to %s: unexpected status %s", rekorURL, resp.Status)
+	}
+
+	// The response is a JSON object keyed by the entry's UUID, with exactly
+	// one entry for a single submitted hashedrekord.
+	var entries map[string]struct {
+		IntegratedTime int64 `json:"integratedTime"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return 0, fmt.Errorf("decoding rekor response from %s: %w", rekorURL, err)
+	}
+	for _, e := range entries {
+		return e.IntegratedTime, nil
+	}
+	return 0, fmt.Errorf("submitting to %s: response had no log entry", rekorURL)
+}
@@ -0,0 +1,152 @@
+package sign
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// selfSignedCertPEM generates a throwaway self-signed certificate for
+// tests that need well-formed PEM input without a live Fulcio dependency.
+func selfSignedCertPEM(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-cert"},
+		NotBefore:    time.Unix(0, 0),
+		NotAfter:     time.Unix(0, 0).Add(24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// generateCA generates a throwaway self-signed CA certificate, standing in
+// for a pinned Sigstore Fulcio root in tests.
+func generateCA(t *testing.T, commonName string) (*ecdsa.PrivateKey, *x509.Certificate, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Unix(0, 0),
+		NotAfter:              time.Unix(0, 0).Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return key, cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// issueLeafCert issues a short-lived leaf certificate signed by ca/caKey,
+// standing in for a Fulcio-minted keyless signing certificate in tests.
+func issueLeafCert(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test-leaf"},
+		NotBefore:    time.Unix(0, 0),
+		NotAfter:     time.Unix(0, 0).Add(10 * time.Minute),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestParseCertificate_RejectsNonPEM(t *testing.T) {
+	_, err := parseCertificate([]byte("not a certificate"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no PEM data found")
+}
+
+func TestParseCertificate_ParsesWellFormedCert(t *testing.T) {
+	cert, err := parseCertificate(selfSignedCertPEM(t))
+	require.NoError(t, err)
+	require.Equal(t, "test-cert", cert.Subject.CommonName)
+}
+
+func TestVerifyKeylessSignature_RejectsInvalidCertificate(t *testing.T) {
+	err := VerifyKeylessSignature([]byte("not a certificate"), nil, selfSignedCertPEM(t), "sha256:abcdef", nil, 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "parsing signing certificate")
+}
+
+func TestVerifyKeylessSignature_RejectsMissingTrustedRoot(t *testing.T) {
+	err := VerifyKeylessSignature(selfSignedCertPEM(t), nil, nil, "sha256:abcdef", nil, 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no certificates found")
+}
+
+func TestVerifyKeylessSignature_RejectsCertNotChainedToTrustedRoot(t *testing.T) {
+	// The certificate and the "trusted root" are both self-signed but
+	// unrelated, so the certificate must not verify against it: a
+	// registry-supplied chain alone (the old, vulnerable behavior) must
+	// never be enough to establish trust.
+	err := VerifyKeylessSignature(selfSignedCertPEM(t), nil, selfSignedCertPEM(t), "sha256:abcdef", nil, 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "verifying certificate chain")
+}
+
+func TestVerifyRawKeylessSignature_AcceptsCertChainedToTrustedRootWithValidSignature(t *testing.T) {
+	caKey, caCert, caPEM := generateCA(t, "test-root")
+	leafKey, leafPEM := issueLeafCert(t, caCert, caKey)
+
+	payload := []byte("test payload")
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, leafKey, hash[:])
+	require.NoError(t, err)
+
+	// The leaf is only valid for the 10 minutes starting at the Unix
+	// epoch (see issueLeafCert); verifying against an integratedTime
+	// inside that window, not wall-clock "now", is what makes this pass.
+	integratedTime := time.Unix(0, 0).Add(5 * time.Minute).Unix()
+	require.NoError(t, VerifyRawKeylessSignature(leafPEM, nil, caPEM, payload, sig, integratedTime))
+}
+
+func TestVerifyRawKeylessSignature_RejectsExpiredCertAtVerificationTime(t *testing.T) {
+	// Regression test: verification used to check the certificate chain
+	// against wall-clock "now" instead of the Rekor integratedTime. This
+	// asserts the other half of that fix: an integratedTime truly outside
+	// the leaf's validity window (here, "now") is rejected. The in-window
+	// case is covered by the test above.
+	caKey, caCert, caPEM := generateCA(t, "test-root")
+	leafKey, leafPEM := issueLeafCert(t, caCert, caKey)
+
+	payload := []byte("test payload")
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, leafKey, hash[:])
+	require.NoError(t, err)
+
+	err = VerifyRawKeylessSignature(leafPEM, nil, caPEM, payload, sig, time.Now().Unix())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "verifying certificate chain")
+}